@@ -0,0 +1,32 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// CleanupPolicy determines whether the objects created for a benchmark
+// are deleted once the benchmark is finished
+// +kubebuilder:validation:Enum=Never;OnSuccess;Always
+type CleanupPolicy string
+
+const (
+	// CleanupNever keeps the objects of the finished benchmarks
+	CleanupNever CleanupPolicy = "Never"
+	// CleanupOnSuccess deletes the objects of the succeeded benchmarks,
+	// the objects of the failed ones are kept for troubleshooting
+	CleanupOnSuccess CleanupPolicy = "OnSuccess"
+	// CleanupAlways deletes the objects of the finished benchmarks
+	CleanupAlways CleanupPolicy = "Always"
+)
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BenchmarkEnvironment describes where the benchmark has been executed,
+// so results can be compared with the ones of similar environments
+type BenchmarkEnvironment struct {
+	// Nodes are the nodes the pods of the benchmark have run on
+	// +optional
+	Nodes []NodeFingerprint `json:"nodes,omitempty"`
+
+	// Volumes are the PersistentVolumeClaims generated for the benchmark
+	// +optional
+	Volumes []VolumeFingerprint `json:"volumes,omitempty"`
+}
+
+// NodeFingerprint describes a node the benchmark has run on
+type NodeFingerprint struct {
+	// Name of the node
+	Name string `json:"name"`
+
+	// Pods are the names of the benchmark pods which have run on the node
+	// +optional
+	Pods []string `json:"pods,omitempty"`
+
+	// InstanceType is the instance type label of the node
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Region is the region label of the node
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Zone is the zone label of the node
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Architecture reported by the node
+	// +optional
+	Architecture string `json:"architecture,omitempty"`
+
+	// OSImage reported by the node
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
+
+	// KernelVersion reported by the node
+	// +optional
+	KernelVersion string `json:"kernelVersion,omitempty"`
+
+	// ContainerRuntimeVersion reported by the node
+	// +optional
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
+
+	// KubeletVersion reported by the node
+	// +optional
+	KubeletVersion string `json:"kubeletVersion,omitempty"`
+
+	// Capacity is the CPU and memory capacity of the node
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+}
+
+// VolumeFingerprint describes a volume used by the benchmark
+type VolumeFingerprint struct {
+	// ClaimName is the name of the PersistentVolumeClaim
+	ClaimName string `json:"claimName"`
+
+	// VolumeName is the name of the bound PersistentVolume
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// StorageClassName is the StorageClass of the claim
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Provisioner of the StorageClass
+	// +optional
+	Provisioner string `json:"provisioner,omitempty"`
+
+	// Parameters of the StorageClass, e.g. the disk type
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Driver is the CSI driver of the PersistentVolume
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// Capacity of the PersistentVolume
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// AccessModes of the PersistentVolume
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+}
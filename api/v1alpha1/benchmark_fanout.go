@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// FanOutNodeLabel is the label of the per node metrics of the fanned
+// out benchmarks
+const FanOutNodeLabel = "node"
+
+// FanOutMode determines whether the jobs of a fanned out benchmark run
+// one after the other or at the same time
+// +kubebuilder:validation:Enum=Parallel;Serial
+type FanOutMode string
+
+const (
+	// FanOutParallel runs the jobs on every node at the same time
+	FanOutParallel FanOutMode = "Parallel"
+	// FanOutSerial runs the jobs one node after the other
+	FanOutSerial FanOutMode = "Serial"
+)
+
+// DefaultOutlierTolerance is the allowed relative difference of the per
+// node metrics from their median in percent when the spec does not set it
+const DefaultOutlierTolerance = "10"
+
+// FanOutSpec runs the benchmark on every node matching the selector,
+// with a Job per node
+type FanOutSpec struct {
+	// NodeSelector selects the nodes to run the benchmark on. Every ready
+	// and schedulable node is selected when it is empty.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Mode determines whether the jobs run one after the other or at the
+	// same time. Defaults to Parallel.
+	// +optional
+	Mode FanOutMode `json:"mode,omitempty"`
+
+	// OutlierTolerance is the allowed relative difference of the per node
+	// metrics from their median in percent, e.g. "10". Defaults to 10.
+	// +optional
+	OutlierTolerance string `json:"outlierTolerance,omitempty"`
+}
+
+// FanOutStatus describes the per node jobs of a fanned out benchmark
+type FanOutStatus struct {
+	// Nodes are the nodes selected when the benchmark started
+	// +optional
+	Nodes []FanOutNode `json:"nodes,omitempty"`
+
+	// Outliers are the per node metrics deviating from the median of
+	// the nodes by more than the outlier tolerance
+	// +optional
+	Outliers []MetricOutlier `json:"outliers,omitempty"`
+}
+
+// FanOutNode is the job of a fanned out benchmark on a node
+type FanOutNode struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+
+	// Job is the name of the job running on the node
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// Phase is the outcome of the job once the benchmark has finished
+	// +optional
+	Phase BenchmarkPhase `json:"phase,omitempty"`
+
+	// Message describes the failure of the job
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MetricOutlier is a per node metric deviating from the median of the
+// same metric on the other nodes
+type MetricOutlier struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+
+	// Name of the metric
+	Name string `json:"name"`
+
+	// Labels of the metric apart from the node
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Value of the metric on the node
+	Value string `json:"value"`
+
+	// Median of the metric on the nodes
+	Median string `json:"median"`
+
+	// Delta is the relative difference from the median in percent
+	Delta string `json:"delta"`
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BenchmarkMetric is a single measurement parsed from the output of the benchmark
+type BenchmarkMetric struct {
+	// Name of the metric in snake case, e.g. iops or bits_per_second
+	Name string `json:"name"`
+
+	// Value of the metric as a decimal number. Durations are expressed
+	// in seconds, throughputs in bytes or bits per second.
+	Value string `json:"value"`
+
+	// Unit of the value, e.g. s, B/s or bit/s
+	// +optional
+	Unit string `json:"unit,omitempty"`
+
+	// Labels distinguish the metrics with the same name,
+	// e.g. rw=read and rw=write for fio.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Job is the name of the job whose output the metric was parsed from
+	// +optional
+	Job string `json:"job,omitempty"`
+}
+
+// BenchmarkResults contains the metrics parsed from the output of the
+// finished benchmark pods
+type BenchmarkResults struct {
+	// Metrics parsed from the benchmark output
+	// +optional
+	Metrics []BenchmarkMetric `json:"metrics,omitempty"`
+
+	// CollectionTime is the time when the results were collected
+	// +optional
+	CollectionTime *metav1.Time `json:"collectionTime,omitempty"`
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResultSink is a destination the raw output and the parsed results of
+// the benchmark are delivered to once the benchmark is finished, so they
+// outlive the benchmark jobs and pods. Exactly one of the sink types has
+// to be specified.
+type ResultSink struct {
+	// S3 uploads the results to an S3 compatible bucket (e.g. MinIO)
+	// +optional
+	S3 *S3Sink `json:"s3,omitempty"`
+
+	// PVC copies the results to a directory of a PersistentVolumeClaim
+	// +optional
+	PVC *PVCSink `json:"pvc,omitempty"`
+
+	// HTTP posts the results as a JSON document to an HTTP endpoint
+	// +optional
+	HTTP *HTTPSink `json:"http,omitempty"`
+
+	// ConfigMap stores the results in a ConfigMap in the namespace of the benchmark
+	// +optional
+	ConfigMap *ConfigMapSink `json:"configMap,omitempty"`
+}
+
+// S3Sink describes an S3 compatible bucket. The objects are uploaded
+// under <prefix>/<namespace>/<name>/.
+type S3Sink struct {
+	// Endpoint is the URL of the S3 service, e.g. http://minio.minio:9000
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the name of the existing bucket the results are uploaded to
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of the uploaded objects
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// AccessKeyID refers to the key of a Secret in the namespace of the
+	// benchmark containing the access key id
+	AccessKeyID corev1.SecretKeySelector `json:"accessKeyID"`
+
+	// SecretAccessKey refers to the key of a Secret in the namespace of
+	// the benchmark containing the secret access key
+	SecretAccessKey corev1.SecretKeySelector `json:"secretAccessKey"`
+}
+
+// PVCSink describes a directory of an existing PersistentVolumeClaim in
+// the namespace of the benchmark. The results are copied by a job to
+// <path>/<namespace>/<name>/.
+type PVCSink struct {
+	// ClaimName is the name of the PersistentVolumeClaim
+	ClaimName string `json:"claimName"`
+
+	// Path is the directory relative to the root of the volume
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Image is the image of the job copying the results. Defaults to the
+	// pvcsink image of the image catalog of the operator (alpine:3).
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// HTTPSink describes an HTTP endpoint the results are posted to
+type HTTPSink struct {
+	// URL of the endpoint
+	URL string `json:"url"`
+
+	// Headers are added to the request
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// AuthorizationSecretRef refers to the key of a Secret in the namespace
+	// of the benchmark containing the value of the Authorization header
+	// +optional
+	AuthorizationSecretRef *corev1.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
+}
+
+// ConfigMapSink describes the ConfigMap the results are stored in. As
+// the size of ConfigMaps is limited to 1MiB, it is suitable for
+// benchmarks with a short output only.
+type ConfigMapSink struct {
+	// Name of the ConfigMap. Defaults to <name of the benchmark>-results.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// ResultSinkStatus describes the delivery of the results to a sink
+type ResultSinkStatus struct {
+	// Type of the sink: S3, PVC, HTTP or ConfigMap
+	Type string `json:"type"`
+
+	// Location is where the results have been delivered to,
+	// e.g. s3://bucket/prefix/namespace/name/
+	// +optional
+	Location string `json:"location,omitempty"`
+
+	// Error is the reason of the failed delivery
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Pending is set while the sink is still delivering the results,
+	// e.g. the job of the PVC sink is copying them to the volume
+	// +optional
+	Pending bool `json:"pending,omitempty"`
+}
+
+// LogArchive refers to the archived output of the containers of the benchmark
+type LogArchive struct {
+	// ConfigMap is the name of the ConfigMap holding the archive
+	ConfigMap string `json:"configMap"`
+
+	// Key of the archive, a gzip compressed tarball with a <pod>_<container>.log
+	// file per container, in the binaryData of the ConfigMap
+	Key string `json:"key"`
+
+	// Containers is the number of containers whose output is archived
+	Containers int32 `json:"containers"`
+
+	// Truncated is true if the beginning of some of the outputs has been
+	// dropped to fit the archive into the size limit of ConfigMaps
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BenchmarkSpec holds the fields every benchmark type has in its spec:
+// the acceptance criteria, the result sinks, and how the benchmark is
+// run and cleaned up. It is inlined into the spec of the benchmarks.
+// Changing these fields does not start a new run of a finished benchmark.
+type BenchmarkSpec struct {
+	// Thresholds are the acceptance criteria of the benchmark, evaluated
+	// against the metrics parsed from its output. The result is recorded
+	// in the Passed condition.
+	// +optional
+	Thresholds []MetricThreshold `json:"thresholds,omitempty"`
+
+	// Sinks are the destinations the raw output and the results of the
+	// benchmark are delivered to once it is finished
+	// +optional
+	Sinks []ResultSink `json:"sinks,omitempty"`
+
+	// Timeout caps the runtime of the benchmark, including the time spent
+	// waiting for its servers to become ready, e.g. 30m. When it expires,
+	// the objects of the benchmark are removed and it fails as TimedOut.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// CleanupPolicy determines whether the objects created for the benchmark
+	// (Jobs, StatefulSets, Services, ConfigMaps, generated PVCs, etc.) are
+	// deleted once it is finished. The CR with its results and logs is kept.
+	// Defaults to Never, or to Always when TTLSecondsAfterFinished is set.
+	// +optional
+	CleanupPolicy CleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// TTLSecondsAfterFinished delays the cleanup of the finished benchmark
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// Suspend stops the running benchmark: its jobs are deleted and its
+	// servers are scaled down, then it is moved to the Cancelled phase.
+	// Unsetting it starts a new run of the cancelled benchmark, changing
+	// it does not rerun a benchmark which has finished otherwise.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Exclusive benchmarks do not share their nodes with other benchmarks.
+	// Benchmarks without NodeName or NodeSelector may run on any node, so
+	// they do not run alongside exclusive benchmarks at all.
+	// +optional
+	Exclusive bool `json:"exclusive,omitempty"`
+}
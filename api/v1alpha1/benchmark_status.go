@@ -16,10 +16,334 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BenchmarkPhase is a simple, high-level summary of where the benchmark
+// is in its lifecycle.
+// +kubebuilder:validation:Enum=Pending;Queued;Validating;Provisioning;Running;Succeeded;Failed;Cancelled
+type BenchmarkPhase string
+
+const (
+	// BenchmarkPending means that the benchmark has been accepted, but
+	// its processing has not started yet
+	BenchmarkPending BenchmarkPhase = "Pending"
+	// BenchmarkQueued means that the benchmark waits for the benchmarks
+	// running on the cluster, in its namespace or on its nodes to finish
+	BenchmarkQueued BenchmarkPhase = "Queued"
+	// BenchmarkValidating means that the benchmark definition is being validated
+	BenchmarkValidating BenchmarkPhase = "Validating"
+	// BenchmarkProvisioning means that the kubernetes resources of the
+	// benchmark are being created and the benchmark waits for them to become ready
+	BenchmarkProvisioning BenchmarkPhase = "Provisioning"
+	// BenchmarkRunning means that the benchmark is being executed
+	BenchmarkRunning BenchmarkPhase = "Running"
+	// BenchmarkSucceeded means that the benchmark has completed successfully
+	BenchmarkSucceeded BenchmarkPhase = "Succeeded"
+	// BenchmarkFailed means that the benchmark has terminated unsuccessfully
+	BenchmarkFailed BenchmarkPhase = "Failed"
+	// BenchmarkCancelled means that the benchmark has been stopped before completion
+	BenchmarkCancelled BenchmarkPhase = "Cancelled"
+)
+
+// Condition types used in BenchmarkStatus.Conditions
+const (
+	// BenchmarkConditionValid reports whether the benchmark definition passed validation
+	BenchmarkConditionValid = "Valid"
+	// BenchmarkConditionDeployed reports whether the server side resources
+	// of the benchmark (e.g. StatefulSets) are deployed
+	BenchmarkConditionDeployed = "Deployed"
+	// BenchmarkConditionComplete is true once the benchmark has completed successfully
+	BenchmarkConditionComplete = "Complete"
+	// BenchmarkConditionFailed is true once the benchmark has terminated unsuccessfully
+	BenchmarkConditionFailed = "Failed"
+	// BenchmarkConditionPassed reports whether the results of the finished
+	// benchmark satisfy the thresholds of its spec
+	BenchmarkConditionPassed = "Passed"
+	// BenchmarkConditionCleanedUp is true once the objects of the finished
+	// benchmark have been deleted according to its cleanup policy
+	BenchmarkConditionCleanedUp = "CleanedUp"
+	// BenchmarkConditionResultsDelivered reports whether the results of the
+	// finished benchmark have been delivered to the sinks of its spec
+	BenchmarkConditionResultsDelivered = "ResultsDelivered"
+)
+
+// BenchmarkCondition describes one aspect of the current state of a benchmark.
+// Its fields follow the upstream metav1.Condition type.
+type BenchmarkCondition struct {
+	// Type of condition in CamelCase.
+	Type string `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// ObservedGeneration represents the .metadata.generation that the condition was set based upon.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Reason contains a programmatic identifier indicating the reason for the condition's last transition.
+	Reason string `json:"reason"`
+
+	// Message is a human readable message indicating details about the transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // BenchmarkStatus describes the current state of the benchmark
 type BenchmarkStatus struct {
-	// Running shows the state of execution
-	Running bool `json:"running"`
-	// Completed shows the state of completion
-	Completed bool `json:"completed"`
+	// Phase is a high-level summary of where the benchmark is in its lifecycle
+	// +optional
+	Phase BenchmarkPhase `json:"phase,omitempty"`
+
+	// Conditions contains the latest available observations of the benchmark's state
+	// +optional
+	Conditions []BenchmarkCondition `json:"conditions,omitempty"`
+
+	// QueuePosition is the position of the Queued benchmark in the queue
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
+	// StartTime is the time when the processing of the benchmark has started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the benchmark has reached a terminal phase
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the benchmark
+	// that was observed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Message is a human readable description of the current state
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Results contains the metrics parsed from the output of the benchmark
+	// +optional
+	Results *BenchmarkResults `json:"results,omitempty"`
+
+	// Baseline contains the comparison of the results with the
+	// BenchmarkBaseline selecting the benchmark
+	// +optional
+	Baseline *BaselineComparison `json:"baseline,omitempty"`
+
+	// Logs refers to the archived output of the containers of the benchmark
+	// +optional
+	Logs *LogArchive `json:"logs,omitempty"`
+
+	// Environment describes the nodes and the volumes of the benchmark
+	// +optional
+	Environment *BenchmarkEnvironment `json:"environment,omitempty"`
+
+	// FanOut describes the per node jobs of the fanned out benchmark
+	// +optional
+	FanOut *FanOutStatus `json:"fanOut,omitempty"`
+
+	// Sinks describes the delivery of the results to the sinks of the benchmark
+	// +optional
+	Sinks []ResultSinkStatus `json:"sinks,omitempty"`
+
+	// Run is the number of the current run of the benchmark, starting at 1
+	// +optional
+	Run int32 `json:"run,omitempty"`
+
+	// ObservedRerun is the value of the rerun annotation of the benchmark
+	// when the current run was started
+	// +optional
+	ObservedRerun string `json:"observedRerun,omitempty"`
+
+	// ObservedSpecHash is the hash of the fields of the spec defining the
+	// benchmark when the current run was started. The fields controlling
+	// its lifecycle (e.g. suspend) are not part of it.
+	// +optional
+	ObservedSpecHash string `json:"observedSpecHash,omitempty"`
+
+	// Images are the container images of the current run, including the
+	// defaults of the image catalog of the operator. The defaults are not
+	// written to the spec, so changing the catalog does not change the spec.
+	// +optional
+	Images []string `json:"images,omitempty"`
+
+	// History contains the status of the previous runs of the benchmark,
+	// the most recent first
+	// +optional
+	History []BenchmarkRun `json:"history,omitempty"`
+}
+
+// MaxRunHistory is the number of previous runs kept in the status
+const MaxRunHistory = 10
+
+// BenchmarkRun is the status of a previous run of the benchmark
+type BenchmarkRun struct {
+	// Run is the number of the run
+	Run int32 `json:"run"`
+
+	// ObservedGeneration is the generation of the benchmark the run was started for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the terminal phase of the run
+	Phase BenchmarkPhase `json:"phase"`
+
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	Results *BenchmarkResults `json:"results,omitempty"`
+
+	// +optional
+	Baseline *BaselineComparison `json:"baseline,omitempty"`
+
+	// +optional
+	Logs *LogArchive `json:"logs,omitempty"`
+
+	// +optional
+	Environment *BenchmarkEnvironment `json:"environment,omitempty"`
+
+	// +optional
+	FanOut *FanOutStatus `json:"fanOut,omitempty"`
+
+	// +optional
+	Sinks []ResultSinkStatus `json:"sinks,omitempty"`
+}
+
+// RunName returns the name of the objects of the given run of the
+// benchmark: the name of the benchmark for the first run and
+// <name>-run<run> for the subsequent ones
+func RunName(name string, run int32) string {
+	if run <= 1 {
+		return name
+	}
+	return fmt.Sprintf("%s-run%d", name, run)
+}
+
+// StartNewRun moves the current run to the history and resets the status
+// for the next run. The runs dropped from the bounded history are returned.
+func (s *BenchmarkStatus) StartNewRun() (dropped []BenchmarkRun) {
+	run := BenchmarkRun{
+		Run:                s.Run,
+		ObservedGeneration: s.ObservedGeneration,
+		Phase:              s.Phase,
+		StartTime:          s.StartTime,
+		CompletionTime:     s.CompletionTime,
+		Message:            s.Message,
+		Results:            s.Results,
+		Baseline:           s.Baseline,
+		Logs:               s.Logs,
+		Environment:        s.Environment,
+		FanOut:             s.FanOut,
+		Sinks:              s.Sinks,
+	}
+	if run.Run == 0 {
+		run.Run = 1
+	}
+
+	s.History = append([]BenchmarkRun{run}, s.History...)
+	if len(s.History) > MaxRunHistory {
+		dropped = s.History[MaxRunHistory:]
+		s.History = s.History[:MaxRunHistory]
+	}
+
+	*s = BenchmarkStatus{
+		Run:                run.Run + 1,
+		ObservedGeneration: s.ObservedGeneration,
+		ObservedRerun:      s.ObservedRerun,
+		ObservedSpecHash:   s.ObservedSpecHash,
+		History:            s.History,
+	}
+	return dropped
+}
+
+// Finished returns true if the benchmark has reached a terminal phase
+func (s *BenchmarkStatus) Finished() bool {
+	switch s.Phase {
+	case BenchmarkSucceeded, BenchmarkFailed, BenchmarkCancelled:
+		return true
+	}
+	return false
+}
+
+// SetPhase moves the benchmark to the given phase. StartTime is recorded
+// when the provisioning of the benchmark begins and CompletionTime is
+// recorded when a terminal phase is reached.
+func (s *BenchmarkStatus) SetPhase(phase BenchmarkPhase, message string) {
+	now := metav1.Now()
+	s.Phase = phase
+	s.Message = message
+	if s.StartTime == nil && phase != BenchmarkPending && phase != BenchmarkValidating &&
+		phase != BenchmarkQueued {
+		s.StartTime = &now
+	}
+	if s.CompletionTime == nil && s.Finished() {
+		s.CompletionTime = &now
+	}
+}
+
+// MarkSucceeded moves the benchmark to the Succeeded phase
+func (s *BenchmarkStatus) MarkSucceeded(message string) {
+	s.SetPhase(BenchmarkSucceeded, message)
+	s.SetCondition(BenchmarkConditionComplete, corev1.ConditionTrue, "Succeeded", message)
+}
+
+// MarkFailed moves the benchmark to the Failed phase with the given reason
+func (s *BenchmarkStatus) MarkFailed(reason, message string) {
+	s.SetPhase(BenchmarkFailed, message)
+	s.SetCondition(BenchmarkConditionFailed, corev1.ConditionTrue, reason, message)
+}
+
+// MarkCancelled moves the benchmark to the Cancelled phase with the given reason
+func (s *BenchmarkStatus) MarkCancelled(reason, message string) {
+	s.SetPhase(BenchmarkCancelled, message)
+	s.SetCondition(BenchmarkConditionComplete, corev1.ConditionFalse, reason, message)
+}
+
+// GetCondition returns the condition with the given type or nil if
+// the condition is not present
+func (s *BenchmarkStatus) GetCondition(conditionType string) *BenchmarkCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns true if the condition with the given type is present
+// and its status is True
+func (s *BenchmarkStatus) IsConditionTrue(conditionType string) bool {
+	condition := s.GetCondition(conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// SetCondition adds or updates the condition with the given type. The
+// LastTransitionTime is only updated if the status of the condition changes.
+func (s *BenchmarkStatus) SetCondition(conditionType string, status corev1.ConditionStatus, reason, message string) {
+	condition := s.GetCondition(conditionType)
+	if condition == nil {
+		s.Conditions = append(s.Conditions, BenchmarkCondition{Type: conditionType})
+		condition = &s.Conditions[len(s.Conditions)-1]
+	}
+
+	if condition.Status != status {
+		condition.Status = status
+		condition.LastTransitionTime = metav1.Now()
+	}
+	condition.Reason = reason
+	condition.Message = message
+	condition.ObservedGeneration = s.ObservedGeneration
 }
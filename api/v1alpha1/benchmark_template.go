@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BenchmarkTemplate describes a benchmark CR created by higher level
+// CRs, like the BenchmarkSuite
+type BenchmarkTemplate struct {
+	// Kind of the benchmark CR
+	// +kubebuilder:validation:Enum=Drill;EsRally;Fio;Ioping;Iperf3;JMeter;KafkaBench;OcpLogtest;Pgbench;Qperf;S3Bench;Sysbench;YcsbBench
+	Kind string `json:"kind"`
+
+	// Labels are added to the benchmark CR
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Spec of the benchmark CR, it has the same format as the spec of
+	// the CRs of the given kind
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// BenchmarkOutcome summarizes the execution of a benchmark
+// created from a BenchmarkTemplate
+type BenchmarkOutcome struct {
+	// Name of the benchmark CR
+	Name string `json:"name"`
+
+	// Kind of the benchmark CR
+	Kind string `json:"kind"`
+
+	// Phase of the benchmark CR
+	// +optional
+	Phase BenchmarkPhase `json:"phase,omitempty"`
+
+	// Message of the benchmark CR
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Results of the benchmark CR
+	// +optional
+	Results *BenchmarkResults `json:"results,omitempty"`
+}
+
+// Finished returns true if the benchmark has reached a terminal phase
+func (o *BenchmarkOutcome) Finished() bool {
+	status := BenchmarkStatus{Phase: o.Phase}
+	return status.Finished()
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ThresholdOperator compares the value of a metric with the threshold
+// +kubebuilder:validation:Enum=<;<=;>;>=
+type ThresholdOperator string
+
+const (
+	// LessThan requires the metric to be lower than the threshold
+	LessThan ThresholdOperator = "<"
+	// LessThanOrEqual requires the metric to be lower than or equal to the threshold
+	LessThanOrEqual ThresholdOperator = "<="
+	// GreaterThan requires the metric to be higher than the threshold
+	GreaterThan ThresholdOperator = ">"
+	// GreaterThanOrEqual requires the metric to be higher than or equal to the threshold
+	GreaterThanOrEqual ThresholdOperator = ">="
+)
+
+// MetricThreshold is an acceptance criterion of the benchmark, e.g.
+// "the p99 read latency is lower than 2ms"
+type MetricThreshold struct {
+	// Metric is the name of the metric, e.g. latency_seconds
+	Metric string `json:"metric"`
+
+	// Labels select the metrics with the given labels, e.g. percentile=99.
+	// The metrics may have additional labels. Every selected metric has
+	// to satisfy the threshold.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Operator compares the value of the metric with the threshold
+	Operator ThresholdOperator `json:"operator"`
+
+	// Value of the threshold in the unit of the metric (seconds, bytes or
+	// bits per second, etc.), as a Kubernetes quantity, e.g. 2m for 2ms
+	// or 9G for 9 Gbit/s
+	Value string `json:"value"`
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MetricDirection tells whether the higher or the lower values of a metric are better
+// +kubebuilder:validation:Enum=HigherIsBetter;LowerIsBetter
+type MetricDirection string
+
+const (
+	// HigherIsBetter is the direction of throughputs, e.g. iops
+	HigherIsBetter MetricDirection = "HigherIsBetter"
+
+	// LowerIsBetter is the direction of durations, e.g. latencies
+	LowerIsBetter MetricDirection = "LowerIsBetter"
+)
+
+// BaselineVerdict is the outcome of the comparison with the baseline
+type BaselineVerdict string
+
+const (
+	// BaselinePass means the metrics are within the tolerance of the baseline
+	BaselinePass BaselineVerdict = "Pass"
+
+	// BaselineRegressed means at least one metric got worse than the
+	// baseline beyond its tolerance
+	BaselineRegressed BaselineVerdict = "Regressed"
+
+	// BaselineImproved means no metric regressed and at least one got
+	// better than the baseline beyond its tolerance
+	BaselineImproved BaselineVerdict = "Improved"
+)
+
+// MetricTolerance overrides the tolerance of the metrics with the given
+// name and labels
+type MetricTolerance struct {
+	// Metric is the name of the metric, e.g. iops
+	Metric string `json:"metric"`
+
+	// Labels select the metrics with the given labels, e.g. rw=read.
+	// The metrics may have additional labels.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Percent is the allowed relative difference from the baseline,
+	// as a decimal number
+	// +kubebuilder:validation:Pattern=^[0-9]+(\.[0-9]+)?$
+	// +optional
+	Percent string `json:"percent,omitempty"`
+
+	// Direction tells whether the higher or the lower values of the
+	// metric are better. By default it is derived from the unit of the
+	// metric: durations are better lower, throughputs are better higher.
+	// +optional
+	Direction MetricDirection `json:"direction,omitempty"`
+}
+
+// BenchmarkBaselineSpec defines the reference results of the benchmarks
+// of a kind with a label set
+type BenchmarkBaselineSpec struct {
+	// Kind of the benchmark CRs compared with the baseline
+	// +kubebuilder:validation:Enum=Drill;EsRally;Fio;Ioping;Iperf3;JMeter;KafkaBench;OcpLogtest;Pgbench;Qperf;S3Bench;Sysbench;YcsbBench
+	Kind string `json:"kind"`
+
+	// Selector selects the benchmark CRs compared with the baseline by
+	// their labels. An empty selector selects every benchmark CR of the kind.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// BenchmarkRef refers to the succeeded benchmark CR of the kind
+	// (in the namespace of the baseline) whose results are the baseline
+	// +optional
+	BenchmarkRef *corev1.LocalObjectReference `json:"benchmarkRef,omitempty"`
+
+	// Results are the stored results of the baseline. Exactly one of
+	// BenchmarkRef and Results has to be specified. The job of the stored
+	// metrics is the role of the job (see MetricComparison).
+	// +optional
+	Results *BenchmarkResults `json:"results,omitempty"`
+
+	// Tolerance is the allowed relative difference from the baseline in
+	// percent, as a decimal number. Defaults to 5.
+	// +kubebuilder:validation:Pattern=^[0-9]+(\.[0-9]+)?$
+	// +optional
+	Tolerance string `json:"tolerance,omitempty"`
+
+	// Tolerances override the tolerance and the direction of some metrics.
+	// The first matching entry is applied.
+	// +optional
+	Tolerances []MetricTolerance `json:"tolerances,omitempty"`
+}
+
+// MetricComparison is the comparison of a metric with its baseline value
+type MetricComparison struct {
+	// Name of the metric
+	Name string `json:"name"`
+
+	// Labels of the metric
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Job is the role of the job the metric was parsed from, i.e. the name
+	// of the job without the name of the benchmark CR and the run suffix
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// Baseline is the value of the metric in the baseline
+	Baseline string `json:"baseline"`
+
+	// Value is the value of the metric in the benchmark
+	Value string `json:"value"`
+
+	// Delta is the relative difference from the baseline in percent.
+	// It is empty when the baseline value is zero.
+	// +optional
+	Delta string `json:"delta,omitempty"`
+
+	// Verdict of the metric
+	Verdict BaselineVerdict `json:"verdict"`
+}
+
+// BaselineComparison is the outcome of the comparison of the results of
+// a benchmark with its baseline
+type BaselineComparison struct {
+	// Name of the BenchmarkBaseline
+	Name string `json:"name"`
+
+	// Verdict summarizes the comparison of the metrics: Regressed if any
+	// metric regressed, Improved if any metric improved, Pass otherwise
+	Verdict BaselineVerdict `json:"verdict"`
+
+	// Metrics contains the comparison of the metrics present both in the
+	// results and in the baseline
+	// +optional
+	Metrics []MetricComparison `json:"metrics,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.kind"
+// +kubebuilder:printcolumn:name="Benchmark",type="string",JSONPath=".spec.benchmarkRef.name"
+// +kubebuilder:printcolumn:name="Tolerance",type="string",JSONPath=".spec.tolerance"
+
+// BenchmarkBaseline is the Schema for the benchmarkbaselines API
+type BenchmarkBaseline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BenchmarkBaselineSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkBaselineList contains a list of BenchmarkBaseline
+type BenchmarkBaselineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BenchmarkBaseline `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BenchmarkBaseline{}, &BenchmarkBaselineList{})
+}
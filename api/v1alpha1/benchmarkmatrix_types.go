@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BenchmarkMatrixSpec defines a parameter sweep: a benchmark CR is created
+// for every combination of the values of the axes
+type BenchmarkMatrixSpec struct {
+	// Template of the benchmark CRs, the axes override its spec
+	Template BenchmarkTemplate `json:"template"`
+
+	// Axes of the matrix. The benchmarks are executed for the Cartesian
+	// product of the values of the axes.
+	// +kubebuilder:validation:MinItems=1
+	Axes []MatrixAxis `json:"axes"`
+
+	// Parallelism is the maximum number of benchmarks running at the
+	// same time. Defaults to 1, so the benchmarks do not skew each
+	// other's results.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+}
+
+// MatrixAxis is a field of the benchmark spec and the values it takes
+type MatrixAxis struct {
+	// Name of the axis, unique within the matrix
+	Name string `json:"name"`
+
+	// Path of the field in the spec of the template, with dots separating
+	// the field names and brackets indexing the lists
+	// (e.g. `volume.persistentVolumeClaimSpec.storageClassName` or
+	// `builtinJobFiles[0]`). Missing fields are created.
+	Path string `json:"path"`
+
+	// Values of the field. Values of string fields are set verbatim.
+	// Values of other fields which are valid JSON (e.g. numbers, booleans
+	// or objects) are set as such, other values are set as strings.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// BenchmarkMatrixStatus describes the current state of the matrix
+type BenchmarkMatrixStatus struct {
+	BenchmarkStatus `json:",inline"`
+
+	// Points contains the outcome of the benchmarks created so far. Along
+	// with the values of the axes they form the result table of the matrix.
+	// +optional
+	Points []MatrixPointOutcome `json:"points,omitempty"`
+}
+
+// MatrixPointOutcome is the outcome of the benchmark of a point of the matrix
+type MatrixPointOutcome struct {
+	// Values of the axes at the point, keyed by the name of the axis
+	Values map[string]string `json:"values"`
+
+	BenchmarkOutcome `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.template.kind"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+
+// BenchmarkMatrix is the Schema for the benchmarkmatrixes API
+type BenchmarkMatrix struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BenchmarkMatrixSpec   `json:"spec,omitempty"`
+	Status BenchmarkMatrixStatus `json:"status,omitempty"`
+}
+
+// GetBenchmarkStatus returns the aggregated status of the matrix
+func (cr *BenchmarkMatrix) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status.BenchmarkStatus
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkMatrixList contains a list of BenchmarkMatrix
+type BenchmarkMatrixList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BenchmarkMatrix `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BenchmarkMatrix{}, &BenchmarkMatrixList{})
+}
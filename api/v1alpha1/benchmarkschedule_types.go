@@ -0,0 +1,116 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConcurrencyPolicy describes how the scheduled benchmarks are handled
+// when the previous benchmark of the schedule is still running
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows the scheduled benchmarks to run concurrently
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+
+	// ForbidConcurrent skips the new benchmark if the previous one
+	// is still running
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+
+	// ReplaceConcurrent deletes the running benchmark and replaces
+	// it with the new one
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// BenchmarkScheduleSpec defines the benchmark CR created on the schedule
+type BenchmarkScheduleSpec struct {
+	// Schedule in Cron format, see https://en.wikipedia.org/wiki/Cron
+	// (e.g. "0 2 * * *" for every night at 2AM)
+	Schedule string `json:"schedule"`
+
+	// Template of the benchmark CR created on the schedule
+	Template BenchmarkTemplate `json:"template"`
+
+	// ConcurrencyPolicy specifies how to treat the concurrent executions
+	// of the benchmark. As concurrent benchmarks skew each other's results,
+	// it defaults to Forbid.
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Suspend stops the creation of new benchmarks. Running benchmarks
+	// are not affected.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// HistoryLimit is the number of finished benchmarks to retain.
+	// Older benchmarks are deleted. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// BenchmarkScheduleStatus describes the current state of the schedule
+type BenchmarkScheduleStatus struct {
+	// Active contains the benchmarks of the schedule which are not finished yet
+	// +optional
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+
+	// LastScheduleTime is the last time a benchmark was due
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime is the next time a benchmark is due
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Message describes why the schedule is not able to create benchmarks
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Kind",type="string",JSONPath=".spec.template.kind"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+
+// BenchmarkSchedule is the Schema for the benchmarkschedules API
+type BenchmarkSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BenchmarkScheduleSpec   `json:"spec,omitempty"`
+	Status BenchmarkScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkScheduleList contains a list of BenchmarkSchedule
+type BenchmarkScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BenchmarkSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BenchmarkSchedule{}, &BenchmarkScheduleList{})
+}
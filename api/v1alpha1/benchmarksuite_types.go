@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BenchmarkSuiteSpec defines the benchmarks of the suite. The steps are
+// executed sequentially, the benchmarks of a step are executed in parallel.
+type BenchmarkSuiteSpec struct {
+	// Steps of the suite in execution order
+	// +kubebuilder:validation:MinItems=1
+	Steps []BenchmarkSuiteStep `json:"steps"`
+
+	// ContinueOnFailure executes the remaining steps even if a benchmark
+	// of a previous step has failed. By default the suite stops at the
+	// first step with a failed benchmark.
+	// +optional
+	ContinueOnFailure bool `json:"continueOnFailure,omitempty"`
+}
+
+// BenchmarkSuiteStep is a group of benchmarks executed in parallel
+type BenchmarkSuiteStep struct {
+	// Name of the step
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Benchmarks of the step
+	// +kubebuilder:validation:MinItems=1
+	Benchmarks []SuiteBenchmark `json:"benchmarks"`
+}
+
+// SuiteBenchmark is a benchmark of the suite. The benchmark CR is named
+// after the suite and the benchmark: <suite name>-<benchmark name>
+type SuiteBenchmark struct {
+	// Name of the benchmark, unique within the suite
+	Name string `json:"name"`
+
+	BenchmarkTemplate `json:",inline"`
+}
+
+// BenchmarkSuiteStatus describes the current state of the suite
+type BenchmarkSuiteStatus struct {
+	BenchmarkStatus `json:",inline"`
+
+	// CurrentStep is the index of the step being executed
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// Benchmarks contains the outcome of the benchmarks created so far
+	// +optional
+	Benchmarks []SuiteBenchmarkOutcome `json:"benchmarks,omitempty"`
+}
+
+// SuiteBenchmarkOutcome is the outcome of a benchmark of the suite
+type SuiteBenchmarkOutcome struct {
+	// Step is the name (or the index if unnamed) of the step of the benchmark
+	Step string `json:"step"`
+
+	BenchmarkOutcome `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Step",type="integer",JSONPath=".status.currentStep"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+
+// BenchmarkSuite is the Schema for the benchmarksuites API
+type BenchmarkSuite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BenchmarkSuiteSpec   `json:"spec,omitempty"`
+	Status BenchmarkSuiteStatus `json:"status,omitempty"`
+}
+
+// GetBenchmarkStatus returns the aggregated status of the suite
+func (cr *BenchmarkSuite) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status.BenchmarkStatus
+}
+
+// +kubebuilder:object:root=true
+
+// BenchmarkSuiteList contains a list of BenchmarkSuite
+type BenchmarkSuiteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BenchmarkSuite `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BenchmarkSuite{}, &BenchmarkSuiteList{})
+}
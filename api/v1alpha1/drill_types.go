@@ -25,7 +25,9 @@ import (
 // drill [OPTIONS] --benchmark <benchmarkFile>
 type DrillSpec struct {
 	// Image defines the drill docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// BenchmarksVolume holds the content of benchmark files.
 	// The key of the map specifies the filename and the value is the content
@@ -44,12 +46,21 @@ type DrillSpec struct {
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
+
+	// FanOut runs the benchmark on every node matching its selector,
+	// with a Job per node, instead of a single Job
+	// +optional
+	FanOut *FanOutSpec `json:"fanOut,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Drill is the Schema for the drills API
 type Drill struct {
@@ -60,6 +71,30 @@ type Drill struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Drill) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Drill) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark.
+// The fanned out benchmarks run on the pool of the selected nodes.
+func (cr *Drill) GetPodScheduling() []PodSchedulingSpec {
+	if cr.Spec.FanOut != nil {
+		return []PodSchedulingSpec{{NodeSelector: cr.Spec.FanOut.NodeSelector}}
+	}
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
+// GetFanOut returns the fan-out configuration of the benchmark
+func (cr *Drill) GetFanOut() *FanOutSpec {
+	return cr.Spec.FanOut
+}
+
 // +kubebuilder:object:root=true
 
 // DrillList contains a list of Drill
@@ -17,12 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EsRallySpec defines the desired state of EsRally
 type EsRallySpec struct {
 	// Image defines the docker image used for the benchmark
+	// When omitted, the default image of the operator is used.
 	// +optional
 	Image ImageSpec `json:"image,omitempty"`
 
@@ -61,6 +63,8 @@ type EsRallySpec struct {
 
 	// TODO: enable client options for ES authentication/config
 	// https://esrally.readthedocs.io/en/stable/command_line_reference.html#id2
+
+	BenchmarkSpec `json:",inline"`
 }
 
 type EsRallySecurity struct {
@@ -75,7 +79,15 @@ type EsRallySecurity struct {
 // BasicAuth contains basic HTTP authentication credentials.
 type BasicAuth struct {
 	Username string `json:"username"`
-	Password string `json:"password"`
+
+	// Password of the user. Consider using PasswordSecretRef instead.
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// PasswordSecretRef selects the key of a Secret in the namespace of
+	// the benchmark holding the password. Mutually exclusive with Password.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
 }
 
 type EsRallyVolConfig struct {
@@ -83,28 +95,35 @@ type EsRallyVolConfig struct {
 	StorageClass string `json:"storageClass"`
 }
 
-type EsRallyStatus struct {
-	// Running shows the state of execution
-	Running bool `json:"running"`
-	// Completed shows the state of completion
-	Completed bool `json:"completed"`
-	// Deployed shows the state of the StatefulSet needed for testing
-	Deployed bool `json:"deployed"`
-}
-
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Deployed",type="boolean",JSONPath=".status.deployed"
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // EsRally is the Schema for the esrallies API
 type EsRally struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   EsRallySpec   `json:"spec,omitempty"`
-	Status EsRallyStatus `json:"status,omitempty"`
+	Spec   EsRallySpec     `json:"spec,omitempty"`
+	Status BenchmarkStatus `json:"status,omitempty"`
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *EsRally) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *EsRally) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *EsRally) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
 }
 
 // +kubebuilder:object:root=true
@@ -23,7 +23,9 @@ import (
 // FioSpec defines the desired state of Fio
 type FioSpec struct {
 	// Image defines the fio docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// BuiltinJobFiles contains a list of fio job files that are already present
 	// in the docker image
@@ -49,12 +51,21 @@ type FioSpec struct {
 	// Volume contains the configuration for the volume that the fio job should
 	// run on.
 	Volume VolumeSpec `json:"volume"`
+
+	BenchmarkSpec `json:",inline"`
+
+	// FanOut runs the benchmark on every node matching its selector,
+	// with a Job per node, instead of a single Job
+	// +optional
+	FanOut *FanOutSpec `json:"fanOut,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Fio is the Schema for the fios API
 type Fio struct {
@@ -65,6 +76,30 @@ type Fio struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Fio) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Fio) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark.
+// The fanned out benchmarks run on the pool of the selected nodes.
+func (cr *Fio) GetPodScheduling() []PodSchedulingSpec {
+	if cr.Spec.FanOut != nil {
+		return []PodSchedulingSpec{{NodeSelector: cr.Spec.FanOut.NodeSelector}}
+	}
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
+// GetFanOut returns the fan-out configuration of the benchmark
+func (cr *Fio) GetFanOut() *FanOutSpec {
+	return cr.Spec.FanOut
+}
+
 // +kubebuilder:object:root=true
 
 // FioList contains a list of Fio
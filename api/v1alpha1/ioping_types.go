@@ -23,7 +23,9 @@ import (
 // IopingSpec defines the ioping benchmark run
 type IopingSpec struct {
 	// Image defines the ioping docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Args are appended to the predefined ioping parameters
 	// +optional
@@ -37,12 +39,21 @@ type IopingSpec struct {
 	// Volume contains the configuration for the volume that the ioping job should
 	// run on.
 	Volume VolumeSpec `json:"volume"`
+
+	BenchmarkSpec `json:",inline"`
+
+	// FanOut runs the benchmark on every node matching its selector,
+	// with a Job per node, instead of a single Job
+	// +optional
+	FanOut *FanOutSpec `json:"fanOut,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Ioping is the Schema for the iopings API
 type Ioping struct {
@@ -53,6 +64,30 @@ type Ioping struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Ioping) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Ioping) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark.
+// The fanned out benchmarks run on the pool of the selected nodes.
+func (cr *Ioping) GetPodScheduling() []PodSchedulingSpec {
+	if cr.Spec.FanOut != nil {
+		return []PodSchedulingSpec{{NodeSelector: cr.Spec.FanOut.NodeSelector}}
+	}
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
+// GetFanOut returns the fan-out configuration of the benchmark
+func (cr *Ioping) GetFanOut() *FanOutSpec {
+	return cr.Spec.FanOut
+}
+
 // +kubebuilder:object:root=true
 
 // IopingList contains a list of Ioping
@@ -41,7 +41,9 @@ type Iperf3ConfigurationSpec struct {
 // and client pod.
 type Iperf3Spec struct {
 	// Image defines the iperf3 docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// ServerConfiguration contains the configuration of the iperf3 server
 	// +optional
@@ -55,12 +57,16 @@ type Iperf3Spec struct {
 	// If enabled the '--udp' parameter is added to iperf command line args
 	// +optional
 	UDP bool `json:"udp,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Iperf3 is the Schema for the iperf3s API
 type Iperf3 struct {
@@ -71,6 +77,24 @@ type Iperf3 struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Iperf3) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Iperf3) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *Iperf3) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{
+		cr.Spec.ServerConfiguration.PodScheduling,
+		cr.Spec.ClientConfiguration.PodScheduling,
+	}
+}
+
 // +kubebuilder:object:root=true
 
 // Iperf3List contains a list of Iperf3
@@ -29,6 +29,8 @@ type JMeterSpec struct {
 
 	// JMeter controller configuration
 	Controller *JMeterController `json:"controller"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 // JMeterWorkers defines the
@@ -36,7 +38,9 @@ type JMeterWorkers struct {
 	Replicas *int32 `json:"replicas"`
 
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
@@ -54,7 +58,9 @@ type JMeterWorkers struct {
 // JMeterWorkers defines the
 type JMeterController struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
@@ -91,25 +97,42 @@ type JMeterController struct {
 	ClusterDomain string `json:"clusterDomain"`
 }
 
-// JMeterStatus defines the observed state of JMeter
-type JMeterStatus struct {
-	// Running shows the state of execution
-	Running bool `json:"running"`
-	// Completed shows the state of completion
-	Completed bool `json:"completed"`
-	// Valid shows the state of the validation
-	Valid bool `json:"valid"`
-}
-
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // JMeter is the Schema for the jmeters API
 type JMeter struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   JMeterSpec   `json:"spec,omitempty"`
-	Status JMeterStatus `json:"status,omitempty"`
+	Spec   JMeterSpec      `json:"spec,omitempty"`
+	Status BenchmarkStatus `json:"status,omitempty"`
+}
+
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *JMeter) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *JMeter) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *JMeter) GetPodScheduling() []PodSchedulingSpec {
+	var scheduling []PodSchedulingSpec
+	if cr.Spec.Controller != nil {
+		scheduling = append(scheduling, cr.Spec.Controller.Configuration.PodScheduling)
+	}
+	if cr.Spec.Workers != nil {
+		scheduling = append(scheduling, cr.Spec.Workers.Configuration.PodScheduling)
+	}
+	return scheduling
 }
 
 // +kubebuilder:object:root=true
@@ -28,20 +28,27 @@ type PullPolicy string
 
 // ImageSpec defines parameters for docker image executed on Kubernetes
 type ImageSpec struct {
-	// Name is the Docker Image location including the tag
-	Name string `json:"name"`
+	// Name is the Docker Image location including the tag.
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Name string `json:"name,omitempty"`
 
 	// +optional
 	PullPolicy PullPolicy `json:"pullPolicy,omitempty"`
 
-	// PullSecret is an optional list of references to secrets
-	// in the same namespace to use for pulling any of the images
+	// PullSecret is an optional reference to a secret
+	// in the same namespace to use for pulling the image
 	// +optional
 	PullSecret string `json:"pullSecret,omitempty"`
+
+	// PullSecrets are optional references to further secrets
+	// in the same namespace to use for pulling the image
+	// +optional
+	PullSecrets []string `json:"pullSecrets,omitempty"`
 }
 
 // PodSchedulingSpec encapsulates the scheduling related
-// fields of a Kubernetes Pod
+// fields of a Kubernetes Pod.
 type PodSchedulingSpec struct {
 	// Affinity is a group of affinity scheduling rules.
 	// +optional
@@ -63,6 +70,11 @@ type PodSchedulingSpec struct {
 	// requirements.
 	// +optional
 	NodeName string `json:"nodeName,omitempty"`
+
+	// TopologySpreadConstraints describe how the pods are spread across
+	// the topology domains, e.g. zones or nodes.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
 // VolumeSpec contains the Volume Definition used for the benchmarks.
@@ -91,14 +103,19 @@ const GeneratedPVC = "GENERATED"
 // Validate method validates that the provided VolumeSpec meets the
 // requirements:
 // If PersistentVolumeClaimSpec is provided, then the VolumeSource's
-// PersistentVolumClaim's ClaimName should be set to GeneratedPVC
+// PersistentVolumClaim's ClaimName should be set to GeneratedPVC.
+// If the ClaimName is set to GeneratedPVC, then PersistentVolumeClaimSpec
+// should be provided.
 func (v *VolumeSpec) Validate() (ok bool, err error) {
+	pvcSource := v.VolumeSource.PersistentVolumeClaim
 	if v.PersistentVolumeClaimSpec != nil {
-		if v.VolumeSource.PersistentVolumeClaim != nil &&
-			v.VolumeSource.PersistentVolumeClaim.ClaimName != GeneratedPVC {
+		if pvcSource == nil || pvcSource.ClaimName != GeneratedPVC {
 			return false, errors.New("If PersistentVolumeClaimSpec is defined, " +
 				"VolumeSource.PersistentVolumeClaim.ClaimName must be set to " + GeneratedPVC)
 		}
+	} else if pvcSource != nil && pvcSource.ClaimName == GeneratedPVC {
+		return false, errors.New("If VolumeSource.PersistentVolumeClaim.ClaimName is set to " +
+			GeneratedPVC + ", PersistentVolumeClaimSpec must be defined")
 	}
 	return true, nil
 }
@@ -126,4 +143,72 @@ type PodConfigurationSpec struct {
 	// More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ServiceAccountName is the name of the ServiceAccount to run the pod with
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// SecurityContext holds the pod-level security attributes
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// ContainerSecurityContext holds the security attributes of the benchmark containers
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// SecurityProfile fills the security attributes which are not set
+	// explicitly to comply with the given Pod Security Standard
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// PriorityClassName is the priority class of the pod
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RuntimeClassName is the RuntimeClass to run the pod with
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// Env lists additional environment variables of the benchmark containers
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom lists additional sources of environment variables of the benchmark containers
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Volumes lists additional volumes of the pod
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts lists additional mounts of the benchmark containers
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// HostNetwork runs the pod in the network namespace of the host
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// HostPID runs the pod in the pid namespace of the host
+	// +optional
+	HostPID bool `json:"hostPID,omitempty"`
+
+	// DNSPolicy of the pod, e.g. ClusterFirstWithHostNet for host network pods
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies the DNS parameters of the pod
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
 }
+
+// SecurityProfile is a Pod Security Standard the benchmark pods comply with
+// +kubebuilder:validation:Enum=Restricted
+type SecurityProfile string
+
+const (
+	// SecurityProfileRestricted runs the containers as non-root users,
+	// without privilege escalation and capabilities, with the default
+	// seccomp profile of the container runtime
+	SecurityProfileRestricted SecurityProfile = "Restricted"
+)
@@ -23,7 +23,9 @@ import (
 // KafkaBenchSpec defines the desired state of KafkaBench
 type KafkaBenchSpec struct {
 	// Image defines the kafka docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// PodConfig contains the configuration for the benchmark pod, including
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
@@ -34,6 +36,8 @@ type KafkaBenchSpec struct {
 
 	// Tests defines the tests with which to create
 	Tests []KafkaTestSpec `json:"tests"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 // ClusterInfo to be used by the benchmark for ZooKeeper and Kafka Brokers
@@ -71,8 +75,10 @@ type KafkaTestSpec struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // KafkaBench is the Schema for the kafkabenches API
 type KafkaBench struct {
@@ -83,6 +89,21 @@ type KafkaBench struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *KafkaBench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *KafkaBench) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *KafkaBench) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
 // +kubebuilder:object:root=true
 
 // KafkaBenchList contains a list of KafkaBench
@@ -23,7 +23,9 @@ import (
 // OcpLogtestSpec defines the desired state of OcpLogtest
 type OcpLogtestSpec struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// length of each line
 	LineLength int `json:"lineLength,omitempty"`
@@ -41,12 +43,21 @@ type OcpLogtestSpec struct {
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
+
+	// FanOut runs the benchmark on every node matching its selector,
+	// with a Job per node, instead of a single Job
+	// +optional
+	FanOut *FanOutSpec `json:"fanOut,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // OcpLogtest is the Schema for the ocplogtests API
 type OcpLogtest struct {
@@ -57,6 +68,30 @@ type OcpLogtest struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *OcpLogtest) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *OcpLogtest) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark.
+// The fanned out benchmarks run on the pool of the selected nodes.
+func (cr *OcpLogtest) GetPodScheduling() []PodSchedulingSpec {
+	if cr.Spec.FanOut != nil {
+		return []PodSchedulingSpec{{NodeSelector: cr.Spec.FanOut.NodeSelector}}
+	}
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
+// GetFanOut returns the fan-out configuration of the benchmark
+func (cr *OcpLogtest) GetFanOut() *FanOutSpec {
+	return cr.Spec.FanOut
+}
+
 // +kubebuilder:object:root=true
 
 // OcpLogtestList contains a list of OcpLogtest
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,8 +32,15 @@ type PostgresSpec struct {
 	// User is the PostgreSQL user name to connect as
 	User string `json:"user"`
 
-	// Password is to be used if the server demands password authentication
-	Password string `json:"password"`
+	// Password is to be used if the server demands password authentication.
+	// Consider using PasswordSecretRef instead.
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// PasswordSecretRef selects the key of a Secret in the namespace of
+	// the benchmark holding the password. Mutually exclusive with Password.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
 
 	// Database is name of the database
 	Database string `json:"database"`
@@ -41,7 +49,9 @@ type PostgresSpec struct {
 // PgbenchSpec describes a pgbench benchmark job
 type PgbenchSpec struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Postgres contains the configuration parameters for the PostgreSQL database
 	// that will run the benchmark
@@ -59,12 +69,16 @@ type PgbenchSpec struct {
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Pgbench is the Schema for the pgbenches API
 type Pgbench struct {
@@ -75,6 +89,21 @@ type Pgbench struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Pgbench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Pgbench) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *Pgbench) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
 // +kubebuilder:object:root=true
 
 // PgbenchList contains a list of Pgbench
@@ -40,7 +40,9 @@ type QperfConfigurationSpec struct {
 // and client pod.
 type QperfSpec struct {
 	// Image defines the qperf docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// Options are options for the qperf binary
 	// +optional
@@ -56,12 +58,16 @@ type QperfSpec struct {
 	// ClientConfiguration contains the configuration of the qperf client
 	// +optional
 	ClientConfiguration QperfConfigurationSpec `json:"clientConfiguration,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Qperf is the Schema for the qperves API
 type Qperf struct {
@@ -72,6 +78,24 @@ type Qperf struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Qperf) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Qperf) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *Qperf) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{
+		cr.Spec.ServerConfiguration.PodScheduling,
+		cr.Spec.ClientConfiguration.PodScheduling,
+	}
+}
+
 // +kubebuilder:object:root=true
 
 // QperfList contains a list of Qperf
@@ -17,12 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // S3BenchSpec defines the desired state of S3Bench
 type S3BenchSpec struct {
 	// Image defines the warp docker image used for the benchmark
+	// When omitted, the default image of the operator is used.
 	// +optional
 	Image ImageSpec `json:"image,omitempty"`
 
@@ -58,6 +60,8 @@ type S3BenchSpec struct {
 	// Will only be used in "mixed" mode.
 	// +optional
 	MixedDistributionOptions MixedDistributionOptions `json:"mixedDist,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 // S3BenchOptions defines the runtime arguments for the Warp cli
@@ -74,12 +78,24 @@ type S3BenchOptions struct {
 	// +optional
 	Insecure bool `json:"insecure,omitempty"`
 
+	// AccessKey defines the S3 access key. Consider using AccessKeySecretRef instead.
 	// +optional
 	AccessKey string `json:"accessKey,omitempty"`
 
+	// AccessKeySecretRef selects the key of a Secret in the namespace of the
+	// benchmark holding the S3 access key. Mutually exclusive with AccessKey.
+	// +optional
+	AccessKeySecretRef *corev1.SecretKeySelector `json:"accessKeySecretRef,omitempty"`
+
+	// SecretKey defines the S3 secret key. Consider using SecretKeySecretRef instead.
 	// +optional
 	SecretKey string `json:"secretKey,omitempty"`
 
+	// SecretKeySecretRef selects the key of a Secret in the namespace of the
+	// benchmark holding the S3 secret key. Mutually exclusive with SecretKey.
+	// +optional
+	SecretKeySecretRef *corev1.SecretKeySelector `json:"secretKeySecretRef,omitempty"`
+
 	// Tls defines if to use TLS (HTTPS) for transport (default: false)
 	// +optional
 	Tls bool `json:"tls,omitempty"`
@@ -190,7 +206,7 @@ type MixedDistributionOptions struct {
 	// PutDist The amount of PUT operations. (default: 15)
 	// +optional
 	PutDist int32 `json:"put,omitempty"`
-	// DeleteDist The amount of DELETE operations. Must be at least the same as PUT. (default: 10)
+	// DeleteDist The amount of DELETE operations. Must not exceed PUT. (default: 10)
 	// +optional
 	DeleteDist int32 `json:"delete,omitempty"`
 }
@@ -213,8 +229,10 @@ type S3AutoTermOptions struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // S3Bench is the Schema for the s3benches API
 type S3Bench struct {
@@ -225,6 +243,21 @@ type S3Bench struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *S3Bench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *S3Bench) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *S3Bench) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
 // +kubebuilder:object:root=true
 
 // S3BenchList contains a list of S3Bench
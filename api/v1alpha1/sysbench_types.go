@@ -26,7 +26,9 @@ import (
 // to the sysbench benchmarking application.
 type SysbenchSpec struct {
 	// Image defines the sysbench docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	// PodConfig contains the configuration for the benchmark pod, including
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
@@ -46,12 +48,21 @@ type SysbenchSpec struct {
 	// on a particular test. Some tests also implement their own custom commands.
 	// +optional
 	Command string `json:"command,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
+
+	// FanOut runs the benchmark on every node matching its selector,
+	// with a Job per node, instead of a single Job
+	// +optional
+	FanOut *FanOutSpec `json:"fanOut,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // Sysbench is the Schema for the sysbenches API
 type Sysbench struct {
@@ -62,6 +73,30 @@ type Sysbench struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *Sysbench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *Sysbench) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark.
+// The fanned out benchmarks run on the pool of the selected nodes.
+func (cr *Sysbench) GetPodScheduling() []PodSchedulingSpec {
+	if cr.Spec.FanOut != nil {
+		return []PodSchedulingSpec{{NodeSelector: cr.Spec.FanOut.NodeSelector}}
+	}
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
+// GetFanOut returns the fan-out configuration of the benchmark
+func (cr *Sysbench) GetFanOut() *FanOutSpec {
+	return cr.Spec.FanOut
+}
+
 // +kubebuilder:object:root=true
 
 // SysbenchList contains a list of Sysbench
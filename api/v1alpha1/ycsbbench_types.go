@@ -23,7 +23,9 @@ import (
 // YcsbBenchSpec defines the desired state of YcsbBench
 type YcsbBenchSpec struct {
 	// Image defines the docker image used for the benchmark
-	Image ImageSpec `json:"image"`
+	// When omitted, the default image of the operator is used.
+	// +optional
+	Image ImageSpec `json:"image,omitempty"`
 
 	Database string `json:"database"`
 	Workload string `json:"workload"`
@@ -35,6 +37,8 @@ type YcsbBenchSpec struct {
 	// pod labels and scheduling policies (affinity, toleration, node selector...)
 	// +optional
 	PodConfig PodConfigurationSpec `json:"podConfig,omitempty"`
+
+	BenchmarkSpec `json:",inline"`
 }
 
 type YcsbBenchOptions struct {
@@ -44,8 +48,10 @@ type YcsbBenchOptions struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Running",type="boolean",JSONPath=".status.running"
-// +kubebuilder:printcolumn:name="Completed",type="boolean",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Started",type="date",JSONPath=".status.startTime"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completionTime"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
 
 // YcsbBench is the Schema for the ycsbbenches API
 type YcsbBench struct {
@@ -56,6 +62,21 @@ type YcsbBench struct {
 	Status BenchmarkStatus `json:"status,omitempty"`
 }
 
+// GetBenchmarkStatus returns the status of the benchmark
+func (cr *YcsbBench) GetBenchmarkStatus() *BenchmarkStatus {
+	return &cr.Status
+}
+
+// GetBenchmarkSpec returns the fields common to every benchmark type
+func (cr *YcsbBench) GetBenchmarkSpec() *BenchmarkSpec {
+	return &cr.Spec.BenchmarkSpec
+}
+
+// GetPodScheduling returns the scheduling of the pods of the benchmark
+func (cr *YcsbBench) GetPodScheduling() []PodSchedulingSpec {
+	return []PodSchedulingSpec{cr.Spec.PodConfig.PodScheduling}
+}
+
 // +kubebuilder:object:root=true
 
 // YcsbBenchList contains a list of YcsbBench
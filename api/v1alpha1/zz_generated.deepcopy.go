@@ -22,20 +22,566 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaselineComparison) DeepCopyInto(out *BaselineComparison) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]MetricComparison, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaselineComparison.
+func (in *BaselineComparison) DeepCopy() *BaselineComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(BaselineComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkBaseline) DeepCopyInto(out *BenchmarkBaseline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkBaseline.
+func (in *BenchmarkBaseline) DeepCopy() *BenchmarkBaseline {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkBaseline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkBaseline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkBaselineList) DeepCopyInto(out *BenchmarkBaselineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BenchmarkBaseline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkBaselineList.
+func (in *BenchmarkBaselineList) DeepCopy() *BenchmarkBaselineList {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkBaselineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkBaselineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkBaselineSpec) DeepCopyInto(out *BenchmarkBaselineSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BenchmarkRef != nil {
+		in, out := &in.BenchmarkRef, &out.BenchmarkRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(BenchmarkResults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerances != nil {
+		in, out := &in.Tolerances, &out.Tolerances
+		*out = make([]MetricTolerance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkBaselineSpec.
+func (in *BenchmarkBaselineSpec) DeepCopy() *BenchmarkBaselineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkBaselineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkCondition) DeepCopyInto(out *BenchmarkCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkCondition.
+func (in *BenchmarkCondition) DeepCopy() *BenchmarkCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkEnvironment) DeepCopyInto(out *BenchmarkEnvironment) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeFingerprint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeFingerprint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkEnvironment.
+func (in *BenchmarkEnvironment) DeepCopy() *BenchmarkEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkMatrix) DeepCopyInto(out *BenchmarkMatrix) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkMatrix.
+func (in *BenchmarkMatrix) DeepCopy() *BenchmarkMatrix {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkMatrix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkMatrix) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkMatrixList) DeepCopyInto(out *BenchmarkMatrixList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BenchmarkMatrix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkMatrixList.
+func (in *BenchmarkMatrixList) DeepCopy() *BenchmarkMatrixList {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkMatrixList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkMatrixList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkMatrixSpec) DeepCopyInto(out *BenchmarkMatrixSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Axes != nil {
+		in, out := &in.Axes, &out.Axes
+		*out = make([]MatrixAxis, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkMatrixSpec.
+func (in *BenchmarkMatrixSpec) DeepCopy() *BenchmarkMatrixSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkMatrixSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkMatrixStatus) DeepCopyInto(out *BenchmarkMatrixStatus) {
+	*out = *in
+	in.BenchmarkStatus.DeepCopyInto(&out.BenchmarkStatus)
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make([]MatrixPointOutcome, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkMatrixStatus.
+func (in *BenchmarkMatrixStatus) DeepCopy() *BenchmarkMatrixStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkMatrixStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkMetric) DeepCopyInto(out *BenchmarkMetric) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkMetric.
+func (in *BenchmarkMetric) DeepCopy() *BenchmarkMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkOutcome) DeepCopyInto(out *BenchmarkOutcome) {
+	*out = *in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(BenchmarkResults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkOutcome.
+func (in *BenchmarkOutcome) DeepCopy() *BenchmarkOutcome {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkOutcome)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkResults) DeepCopyInto(out *BenchmarkResults) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]BenchmarkMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CollectionTime != nil {
+		in, out := &in.CollectionTime, &out.CollectionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkResults.
+func (in *BenchmarkResults) DeepCopy() *BenchmarkResults {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkResults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkRun) DeepCopyInto(out *BenchmarkRun) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(BenchmarkResults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(BaselineComparison)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(LogArchive)
+		**out = **in
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = new(BenchmarkEnvironment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]ResultSinkStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkRun.
+func (in *BenchmarkRun) DeepCopy() *BenchmarkRun {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSchedule) DeepCopyInto(out *BenchmarkSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSchedule.
+func (in *BenchmarkSchedule) DeepCopy() *BenchmarkSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkScheduleList) DeepCopyInto(out *BenchmarkScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BenchmarkSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkScheduleList.
+func (in *BenchmarkScheduleList) DeepCopy() *BenchmarkScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkScheduleSpec) DeepCopyInto(out *BenchmarkScheduleSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkScheduleSpec.
+func (in *BenchmarkScheduleSpec) DeepCopy() *BenchmarkScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkScheduleStatus) DeepCopyInto(out *BenchmarkScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkScheduleStatus.
+func (in *BenchmarkScheduleStatus) DeepCopy() *BenchmarkScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSpec) DeepCopyInto(out *BenchmarkSpec) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]MetricThreshold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]ResultSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
-func (in *BasicAuth) DeepCopy() *BasicAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSpec.
+func (in *BenchmarkSpec) DeepCopy() *BenchmarkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BasicAuth)
+	out := new(BenchmarkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -43,6 +589,63 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BenchmarkStatus) DeepCopyInto(out *BenchmarkStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]BenchmarkCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(BenchmarkResults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Baseline != nil {
+		in, out := &in.Baseline, &out.Baseline
+		*out = new(BaselineComparison)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = new(LogArchive)
+		**out = **in
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = new(BenchmarkEnvironment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]ResultSinkStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]BenchmarkRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkStatus.
@@ -55,13 +658,177 @@ func (in *BenchmarkStatus) DeepCopy() *BenchmarkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSuite) DeepCopyInto(out *BenchmarkSuite) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSuite.
+func (in *BenchmarkSuite) DeepCopy() *BenchmarkSuite {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSuite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkSuite) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSuiteList) DeepCopyInto(out *BenchmarkSuiteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BenchmarkSuite, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSuiteList.
+func (in *BenchmarkSuiteList) DeepCopy() *BenchmarkSuiteList {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSuiteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BenchmarkSuiteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSuiteSpec) DeepCopyInto(out *BenchmarkSuiteSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]BenchmarkSuiteStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSuiteSpec.
+func (in *BenchmarkSuiteSpec) DeepCopy() *BenchmarkSuiteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSuiteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSuiteStatus) DeepCopyInto(out *BenchmarkSuiteStatus) {
+	*out = *in
+	in.BenchmarkStatus.DeepCopyInto(&out.BenchmarkStatus)
+	if in.Benchmarks != nil {
+		in, out := &in.Benchmarks, &out.Benchmarks
+		*out = make([]SuiteBenchmarkOutcome, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSuiteStatus.
+func (in *BenchmarkSuiteStatus) DeepCopy() *BenchmarkSuiteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSuiteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSuiteStep) DeepCopyInto(out *BenchmarkSuiteStep) {
+	*out = *in
+	if in.Benchmarks != nil {
+		in, out := &in.Benchmarks, &out.Benchmarks
+		*out = make([]SuiteBenchmark, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSuiteStep.
+func (in *BenchmarkSuiteStep) DeepCopy() *BenchmarkSuiteStep {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSuiteStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkTemplate) DeepCopyInto(out *BenchmarkTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkTemplate.
+func (in *BenchmarkTemplate) DeepCopy() *BenchmarkTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapSink) DeepCopyInto(out *ConfigMapSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapSink.
+func (in *ConfigMapSink) DeepCopy() *ConfigMapSink {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Drill) DeepCopyInto(out *Drill) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drill.
@@ -117,7 +884,7 @@ func (in *DrillList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DrillSpec) DeepCopyInto(out *DrillSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	if in.BenchmarksVolume != nil {
 		in, out := &in.BenchmarksVolume, &out.BenchmarksVolume
 		*out = make(map[string]string, len(*in))
@@ -126,6 +893,12 @@ func (in *DrillSpec) DeepCopyInto(out *DrillSpec) {
 		}
 	}
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrillSpec.
@@ -144,7 +917,7 @@ func (in *EsRally) DeepCopyInto(out *EsRally) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EsRally.
@@ -203,7 +976,7 @@ func (in *EsRallySecurity) DeepCopyInto(out *EsRallySecurity) {
 	if in.BasicAuth != nil {
 		in, out := &in.BasicAuth, &out.BasicAuth
 		*out = new(BasicAuth)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -220,7 +993,7 @@ func (in *EsRallySecurity) DeepCopy() *EsRallySecurity {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EsRallySpec) DeepCopyInto(out *EsRallySpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
 	if in.TrackRepository != nil {
 		in, out := &in.TrackRepository, &out.TrackRepository
@@ -254,6 +1027,7 @@ func (in *EsRallySpec) DeepCopyInto(out *EsRallySpec) {
 		*out = new(EsRallySecurity)
 		(*in).DeepCopyInto(*out)
 	}
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EsRallySpec.
@@ -267,31 +1041,80 @@ func (in *EsRallySpec) DeepCopy() *EsRallySpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EsRallyStatus) DeepCopyInto(out *EsRallyStatus) {
+func (in *EsRallyVolConfig) DeepCopyInto(out *EsRallyVolConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EsRallyStatus.
-func (in *EsRallyStatus) DeepCopy() *EsRallyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EsRallyVolConfig.
+func (in *EsRallyVolConfig) DeepCopy() *EsRallyVolConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(EsRallyStatus)
+	out := new(EsRallyVolConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EsRallyVolConfig) DeepCopyInto(out *EsRallyVolConfig) {
+func (in *FanOutNode) DeepCopyInto(out *FanOutNode) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EsRallyVolConfig.
-func (in *EsRallyVolConfig) DeepCopy() *EsRallyVolConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FanOutNode.
+func (in *FanOutNode) DeepCopy() *FanOutNode {
 	if in == nil {
 		return nil
 	}
-	out := new(EsRallyVolConfig)
+	out := new(FanOutNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FanOutSpec) DeepCopyInto(out *FanOutSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FanOutSpec.
+func (in *FanOutSpec) DeepCopy() *FanOutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FanOutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FanOutStatus) DeepCopyInto(out *FanOutStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]FanOutNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outliers != nil {
+		in, out := &in.Outliers, &out.Outliers
+		*out = make([]MetricOutlier, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FanOutStatus.
+func (in *FanOutStatus) DeepCopy() *FanOutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FanOutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -302,7 +1125,7 @@ func (in *Fio) DeepCopyInto(out *Fio) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fio.
@@ -358,7 +1181,7 @@ func (in *FioList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FioSpec) DeepCopyInto(out *FioSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	if in.BuiltinJobFiles != nil {
 		in, out := &in.BuiltinJobFiles, &out.BuiltinJobFiles
 		*out = make([]string, len(*in))
@@ -371,6 +1194,12 @@ func (in *FioSpec) DeepCopyInto(out *FioSpec) {
 	}
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
 	in.Volume.DeepCopyInto(&out.Volume)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FioSpec.
@@ -383,9 +1212,41 @@ func (in *FioSpec) DeepCopy() *FioSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSink) DeepCopyInto(out *HTTPSink) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSink.
+func (in *HTTPSink) DeepCopy() *HTTPSink {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSpec.
@@ -404,7 +1265,7 @@ func (in *Ioping) DeepCopyInto(out *Ioping) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ioping.
@@ -460,9 +1321,15 @@ func (in *IopingList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IopingSpec) DeepCopyInto(out *IopingSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
 	in.Volume.DeepCopyInto(&out.Volume)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IopingSpec.
@@ -481,7 +1348,7 @@ func (in *Iperf3) DeepCopyInto(out *Iperf3) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3.
@@ -553,9 +1420,10 @@ func (in *Iperf3List) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Iperf3Spec) DeepCopyInto(out *Iperf3Spec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
 	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Iperf3Spec.
@@ -574,7 +1442,7 @@ func (in *JMeter) DeepCopyInto(out *JMeter) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JMeter.
@@ -598,7 +1466,7 @@ func (in *JMeter) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JMeterController) DeepCopyInto(out *JMeterController) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.Configuration.DeepCopyInto(&out.Configuration)
 	if in.PlanTest != nil {
 		in, out := &in.PlanTest, &out.PlanTest
@@ -672,6 +1540,7 @@ func (in *JMeterSpec) DeepCopyInto(out *JMeterSpec) {
 		*out = new(JMeterController)
 		(*in).DeepCopyInto(*out)
 	}
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JMeterSpec.
@@ -684,21 +1553,6 @@ func (in *JMeterSpec) DeepCopy() *JMeterSpec {
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JMeterStatus) DeepCopyInto(out *JMeterStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JMeterStatus.
-func (in *JMeterStatus) DeepCopy() *JMeterStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(JMeterStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JMeterWorkers) DeepCopyInto(out *JMeterWorkers) {
 	*out = *in
@@ -707,7 +1561,7 @@ func (in *JMeterWorkers) DeepCopyInto(out *JMeterWorkers) {
 		*out = new(int32)
 		**out = **in
 	}
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.Configuration.DeepCopyInto(&out.Configuration)
 }
 
@@ -727,7 +1581,7 @@ func (in *KafkaBench) DeepCopyInto(out *KafkaBench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBench.
@@ -783,7 +1637,7 @@ func (in *KafkaBenchList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaBenchSpec) DeepCopyInto(out *KafkaBenchSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
 	in.KafkaClusterInfo.DeepCopyInto(&out.KafkaClusterInfo)
 	if in.Tests != nil {
@@ -793,6 +1647,7 @@ func (in *KafkaBenchSpec) DeepCopyInto(out *KafkaBenchSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBenchSpec.
@@ -860,6 +1715,152 @@ func (in *KafkaTestSpec) DeepCopy() *KafkaTestSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogArchive) DeepCopyInto(out *LogArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogArchive.
+func (in *LogArchive) DeepCopy() *LogArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(LogArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixAxis) DeepCopyInto(out *MatrixAxis) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixAxis.
+func (in *MatrixAxis) DeepCopy() *MatrixAxis {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixAxis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixPointOutcome) DeepCopyInto(out *MatrixPointOutcome) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.BenchmarkOutcome.DeepCopyInto(&out.BenchmarkOutcome)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixPointOutcome.
+func (in *MatrixPointOutcome) DeepCopy() *MatrixPointOutcome {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixPointOutcome)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricComparison) DeepCopyInto(out *MetricComparison) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricComparison.
+func (in *MetricComparison) DeepCopy() *MetricComparison {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricComparison)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricOutlier) DeepCopyInto(out *MetricOutlier) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricOutlier.
+func (in *MetricOutlier) DeepCopy() *MetricOutlier {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricOutlier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricThreshold) DeepCopyInto(out *MetricThreshold) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricThreshold.
+func (in *MetricThreshold) DeepCopy() *MetricThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricTolerance) DeepCopyInto(out *MetricTolerance) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricTolerance.
+func (in *MetricTolerance) DeepCopy() *MetricTolerance {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricTolerance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MixedDistributionOptions) DeepCopyInto(out *MixedDistributionOptions) {
 	*out = *in
@@ -875,13 +1876,40 @@ func (in *MixedDistributionOptions) DeepCopy() *MixedDistributionOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFingerprint) DeepCopyInto(out *NodeFingerprint) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFingerprint.
+func (in *NodeFingerprint) DeepCopy() *NodeFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OcpLogtest) DeepCopyInto(out *OcpLogtest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtest.
@@ -937,8 +1965,14 @@ func (in *OcpLogtestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OcpLogtestSpec) DeepCopyInto(out *OcpLogtestSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OcpLogtestSpec.
@@ -951,13 +1985,28 @@ func (in *OcpLogtestSpec) DeepCopy() *OcpLogtestSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCSink) DeepCopyInto(out *PVCSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCSink.
+func (in *PVCSink) DeepCopy() *PVCSink {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pgbench) DeepCopyInto(out *Pgbench) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pgbench.
@@ -1013,9 +2062,10 @@ func (in *PgbenchList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PgbenchSpec) DeepCopyInto(out *PgbenchSpec) {
 	*out = *in
-	out.Image = in.Image
-	out.Postgres = in.Postgres
+	in.Image.DeepCopyInto(&out.Image)
+	in.Postgres.DeepCopyInto(&out.Postgres)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PgbenchSpec.
@@ -1047,6 +2097,54 @@ func (in *PodConfigurationSpec) DeepCopyInto(out *PodConfigurationSpec) {
 	}
 	in.PodScheduling.DeepCopyInto(&out.PodScheduling)
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodConfigurationSpec.
@@ -1081,6 +2179,13 @@ func (in *PodSchedulingSpec) DeepCopyInto(out *PodSchedulingSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSchedulingSpec.
@@ -1096,6 +2201,11 @@ func (in *PodSchedulingSpec) DeepCopy() *PodSchedulingSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresSpec) DeepCopyInto(out *PostgresSpec) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresSpec.
@@ -1114,7 +2224,7 @@ func (in *Qperf) DeepCopyInto(out *Qperf) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Qperf.
@@ -1186,7 +2296,7 @@ func (in *QperfList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QperfSpec) DeepCopyInto(out *QperfSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	if in.Tests != nil {
 		in, out := &in.Tests, &out.Tests
 		*out = make([]string, len(*in))
@@ -1194,6 +2304,7 @@ func (in *QperfSpec) DeepCopyInto(out *QperfSpec) {
 	}
 	in.ServerConfiguration.DeepCopyInto(&out.ServerConfiguration)
 	in.ClientConfiguration.DeepCopyInto(&out.ClientConfiguration)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QperfSpec.
@@ -1206,6 +2317,56 @@ func (in *QperfSpec) DeepCopy() *QperfSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultSink) DeepCopyInto(out *ResultSink) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Sink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PVC != nil {
+		in, out := &in.PVC, &out.PVC
+		*out = new(PVCSink)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSink)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapSink)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultSink.
+func (in *ResultSink) DeepCopy() *ResultSink {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultSinkStatus) DeepCopyInto(out *ResultSinkStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultSinkStatus.
+func (in *ResultSinkStatus) DeepCopy() *ResultSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3AnalysisOptions) DeepCopyInto(out *S3AnalysisOptions) {
 	*out = *in
@@ -1242,7 +2403,7 @@ func (in *S3Bench) DeepCopyInto(out *S3Bench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Bench.
@@ -1298,6 +2459,16 @@ func (in *S3BenchList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3BenchOptions) DeepCopyInto(out *S3BenchOptions) {
 	*out = *in
+	if in.AccessKeySecretRef != nil {
+		in, out := &in.AccessKeySecretRef, &out.AccessKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeySecretRef != nil {
+		in, out := &in.SecretKeySecretRef, &out.SecretKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BenchOptions.
@@ -1313,13 +2484,14 @@ func (in *S3BenchOptions) DeepCopy() *S3BenchOptions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *S3BenchSpec) DeepCopyInto(out *S3BenchSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
-	out.S3BenchOptions = in.S3BenchOptions
+	in.S3BenchOptions.DeepCopyInto(&out.S3BenchOptions)
 	out.S3ObjectOptions = in.S3ObjectOptions
 	out.S3AutoTermOptions = in.S3AutoTermOptions
 	out.S3AnalysisOptions = in.S3AnalysisOptions
 	out.MixedDistributionOptions = in.MixedDistributionOptions
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BenchSpec.
@@ -1347,13 +2519,62 @@ func (in *S3ObjectOptions) DeepCopy() *S3ObjectOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Sink) DeepCopyInto(out *S3Sink) {
+	*out = *in
+	in.AccessKeyID.DeepCopyInto(&out.AccessKeyID)
+	in.SecretAccessKey.DeepCopyInto(&out.SecretAccessKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Sink.
+func (in *S3Sink) DeepCopy() *S3Sink {
+	if in == nil {
+		return nil
+	}
+	out := new(S3Sink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuiteBenchmark) DeepCopyInto(out *SuiteBenchmark) {
+	*out = *in
+	in.BenchmarkTemplate.DeepCopyInto(&out.BenchmarkTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuiteBenchmark.
+func (in *SuiteBenchmark) DeepCopy() *SuiteBenchmark {
+	if in == nil {
+		return nil
+	}
+	out := new(SuiteBenchmark)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuiteBenchmarkOutcome) DeepCopyInto(out *SuiteBenchmarkOutcome) {
+	*out = *in
+	in.BenchmarkOutcome.DeepCopyInto(&out.BenchmarkOutcome)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuiteBenchmarkOutcome.
+func (in *SuiteBenchmarkOutcome) DeepCopy() *SuiteBenchmarkOutcome {
+	if in == nil {
+		return nil
+	}
+	out := new(SuiteBenchmarkOutcome)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sysbench) DeepCopyInto(out *Sysbench) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sysbench.
@@ -1409,8 +2630,14 @@ func (in *SysbenchList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SysbenchSpec) DeepCopyInto(out *SysbenchSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(FanOutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysbenchSpec.
@@ -1423,6 +2650,40 @@ func (in *SysbenchSpec) DeepCopy() *SysbenchSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeFingerprint) DeepCopyInto(out *VolumeFingerprint) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeFingerprint.
+func (in *VolumeFingerprint) DeepCopy() *VolumeFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSpec) DeepCopyInto(out *VolumeSpec) {
 	*out = *in
@@ -1450,7 +2711,7 @@ func (in *YcsbBench) DeepCopyInto(out *YcsbBench) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBench.
@@ -1521,7 +2782,7 @@ func (in *YcsbBenchOptions) DeepCopy() *YcsbBenchOptions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *YcsbBenchSpec) DeepCopyInto(out *YcsbBenchSpec) {
 	*out = *in
-	out.Image = in.Image
+	in.Image.DeepCopyInto(&out.Image)
 	out.Options = in.Options
 	if in.Properties != nil {
 		in, out := &in.Properties, &out.Properties
@@ -1531,6 +2792,7 @@ func (in *YcsbBenchSpec) DeepCopyInto(out *YcsbBenchSpec) {
 		}
 	}
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	in.BenchmarkSpec.DeepCopyInto(&out.BenchmarkSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YcsbBenchSpec.
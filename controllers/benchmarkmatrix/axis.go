@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkmatrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// point is a combination of the values of the axes of the matrix along
+// with the template of its benchmark
+type point struct {
+	values   map[string]string
+	template perfv1alpha1.BenchmarkTemplate
+}
+
+// pathElement is a field name or a list index of a path
+type pathElement struct {
+	field   string
+	index   int
+	isIndex bool
+}
+
+var pathSegment = regexp.MustCompile(`^([^.\[\]]+)((?:\[[0-9]+\])*)$`)
+
+// pointCount returns the number of points of the matrix
+func pointCount(axes []perfv1alpha1.MatrixAxis) int {
+	count := 1
+	for _, axis := range axes {
+		count *= len(axis.Values)
+	}
+	return count
+}
+
+// expand returns the points of the Cartesian product of the axes in
+// lexicographic order: the values of the last axis vary the fastest
+func expand(cr *perfv1alpha1.BenchmarkMatrix, scheme *runtime.Scheme) ([]point, error) {
+	specType := templateSpecType(scheme, cr.Spec.Template.Kind)
+	count := pointCount(cr.Spec.Axes)
+	points := make([]point, 0, count)
+	for i := 0; i < count; i++ {
+		spec := map[string]interface{}{}
+		if len(cr.Spec.Template.Spec.Raw) > 0 {
+			if err := json.Unmarshal(cr.Spec.Template.Spec.Raw, &spec); err != nil {
+				return nil, fmt.Errorf("invalid template spec: %v", err)
+			}
+		}
+
+		values := map[string]string{}
+		remainder := i
+		for a := len(cr.Spec.Axes) - 1; a >= 0; a-- {
+			axis := &cr.Spec.Axes[a]
+			value := axis.Values[remainder%len(axis.Values)]
+			remainder /= len(axis.Values)
+
+			values[axis.Name] = value
+			elements, err := parsePath(axis.Path)
+			if err != nil {
+				return nil, fmt.Errorf("axis %s: %v", axis.Name, err)
+			}
+			var fieldValue interface{} = value
+			if fieldKind(specType, elements) != reflect.String {
+				fieldValue = parseValue(value)
+			}
+			if err := setPath(spec, axis.Path, fieldValue); err != nil {
+				return nil, fmt.Errorf("axis %s: %v", axis.Name, err)
+			}
+		}
+
+		raw, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+		template := *cr.Spec.Template.DeepCopy()
+		template.Spec.Raw = raw
+		points = append(points, point{values: values, template: template})
+	}
+	return points, nil
+}
+
+// parseValue returns the value decoded from JSON if the value is valid
+// JSON, otherwise the value itself. It is used for the fields which are
+// not strings, the values of string fields are set verbatim.
+func parseValue(value string) interface{} {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return value
+	}
+	return parsed
+}
+
+// templateSpecType returns the type of the spec of the benchmark kind, or
+// nil if the kind is unknown
+func templateSpecType(scheme *runtime.Scheme, kind string) reflect.Type {
+	object, err := scheme.New(perfv1alpha1.GroupVersion.WithKind(kind))
+	if err != nil {
+		return nil
+	}
+	spec, ok := reflect.Indirect(reflect.ValueOf(object)).Type().FieldByName("Spec")
+	if !ok {
+		return nil
+	}
+	return spec.Type
+}
+
+// fieldKind returns the kind of the field at the path in the spec type,
+// or reflect.Invalid if the path does not lead to a field of the type
+func fieldKind(specType reflect.Type, path []pathElement) reflect.Kind {
+	fieldType := specType
+	for _, element := range path {
+		if fieldType == nil {
+			return reflect.Invalid
+		}
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case element.isIndex && (fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array):
+			fieldType = fieldType.Elem()
+		case !element.isIndex && fieldType.Kind() == reflect.Map:
+			fieldType = fieldType.Elem()
+		case !element.isIndex && fieldType.Kind() == reflect.Struct:
+			fieldType = jsonFieldType(fieldType, element.field)
+		default:
+			return reflect.Invalid
+		}
+	}
+	if fieldType == nil {
+		return reflect.Invalid
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Kind()
+}
+
+// jsonFieldType returns the type of the field of the struct type with the
+// given JSON name, looking into inlined structs as well, or nil if there
+// is no such field
+func jsonFieldType(structType reflect.Type, name string) reflect.Type {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tagName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tagName == name {
+			return field.Type
+		}
+		if tagName == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if fieldType := jsonFieldType(field.Type, name); fieldType != nil {
+				return fieldType
+			}
+		}
+	}
+	return nil
+}
+
+// parsePath splits the path (e.g. `jobs[0].name`) into its elements
+func parsePath(path string) ([]pathElement, error) {
+	var elements []pathElement
+	for _, segment := range strings.Split(path, ".") {
+		match := pathSegment.FindStringSubmatch(segment)
+		if match == nil {
+			return nil, fmt.Errorf("invalid path '%s'", path)
+		}
+		elements = append(elements, pathElement{field: match[1]})
+		for _, index := range strings.Split(strings.Trim(match[2], "[]"), "][") {
+			if index == "" {
+				continue
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path '%s': %v", path, err)
+			}
+			elements = append(elements, pathElement{index: i, isIndex: true})
+		}
+	}
+	return elements, nil
+}
+
+// setPath sets the field of the spec at the path to the value
+func setPath(spec map[string]interface{}, path string, value interface{}) error {
+	elements, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if _, err := setElement(spec, elements, value); err != nil {
+		return fmt.Errorf("unable to set '%s': %v", path, err)
+	}
+	return nil
+}
+
+// setElement sets the value at the path relative to the node and returns
+// the updated node. Missing objects are created, missing list items are not.
+func setElement(node interface{}, path []pathElement, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	element := path[0]
+	if !element.isIndex {
+		object, ok := node.(map[string]interface{})
+		if node == nil {
+			object, ok = map[string]interface{}{}, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s is not a field of an object", element.field)
+		}
+		child, err := setElement(object[element.field], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		object[element.field] = child
+		return object, nil
+	}
+
+	list, ok := node.([]interface{})
+	if !ok || element.index >= len(list) {
+		return nil, fmt.Errorf("index %d is out of range", element.index)
+	}
+	child, err := setElement(list[element.index], path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	list[element.index] = child
+	return list, nil
+}
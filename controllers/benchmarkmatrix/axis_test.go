@@ -0,0 +1,160 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkmatrix
+
+import (
+	"encoding/json"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ksapi "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Matrix axes", func() {
+	var scheme *runtime.Scheme
+	var cr ksapi.BenchmarkMatrix
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ksapi.AddToScheme(scheme)).To(Succeed())
+		cr = newMatrix()
+	})
+
+	It("should expand the Cartesian product of the axes", func() {
+		points, err := expand(&cr, scheme)
+		Expect(err).To(BeNil())
+		Expect(points).To(HaveLen(4))
+		Expect(points[0].values).To(Equal(map[string]string{"job": "/jobs/rand-read.fio", "size": "--size=1Gi"}))
+		Expect(points[1].values).To(Equal(map[string]string{"job": "/jobs/rand-read.fio", "size": "--size=10Gi"}))
+		Expect(points[2].values).To(Equal(map[string]string{"job": "/jobs/rand-write.fio", "size": "--size=1Gi"}))
+		Expect(points[3].values).To(Equal(map[string]string{"job": "/jobs/rand-write.fio", "size": "--size=10Gi"}))
+	})
+
+	It("should set the values of the point in the template", func() {
+		points, err := expand(&cr, scheme)
+		Expect(err).To(BeNil())
+
+		var spec ksapi.FioSpec
+		Expect(json.Unmarshal(points[3].template.Spec.Raw, &spec)).To(Succeed())
+		Expect(spec.BuiltinJobFiles).To(Equal([]string{"/jobs/rand-write.fio"}))
+		Expect(spec.CmdLineArgs).To(Equal("--size=10Gi"))
+		Expect(points[3].template.Kind).To(Equal("Fio"))
+	})
+
+	It("should not modify the template of the matrix", func() {
+		_, err := expand(&cr, scheme)
+		Expect(err).To(BeNil())
+		Expect(string(cr.Spec.Template.Spec.Raw)).To(Equal(`{"builtinJobFiles":["/jobs/rand-read.fio"]}`))
+	})
+
+	It("should keep numeric-looking values of string fields as strings", func() {
+		cr.Spec.Axes = []ksapi.MatrixAxis{
+			{Name: "args", Path: "cmdLineArgs", Values: []string{"8", "1.0", "true"}},
+		}
+		points, err := expand(&cr, scheme)
+		Expect(err).To(BeNil())
+
+		var spec ksapi.FioSpec
+		for index, args := range []string{"8", "1.0", "true"} {
+			Expect(json.Unmarshal(points[index].template.Spec.Raw, &spec)).To(Succeed())
+			Expect(spec.CmdLineArgs).To(Equal(args))
+		}
+	})
+
+	It("should decode the values of other fields from JSON", func() {
+		cr.Spec.Template = ksapi.BenchmarkTemplate{
+			Kind: "KafkaBench",
+			Spec: runtime.RawExtension{Raw: []byte(`{"tests":[{"name":"write"}]}`)},
+		}
+		cr.Spec.Axes = []ksapi.MatrixAxis{
+			{Name: "name", Path: "tests[0].name", Values: []string{"8"}},
+			{Name: "threads", Path: "tests[0].threads", Values: []string{"8"}},
+		}
+		points, err := expand(&cr, scheme)
+		Expect(err).To(BeNil())
+
+		var spec ksapi.KafkaBenchSpec
+		Expect(json.Unmarshal(points[0].template.Spec.Raw, &spec)).To(Succeed())
+		Expect(spec.Tests[0].Name).To(Equal("8"))
+		Expect(spec.Tests[0].Threads).To(Equal(int32(8)))
+	})
+
+	It("should resolve the kind of the fields of the spec", func() {
+		specType := templateSpecType(scheme, "Fio")
+		kind := func(path string) reflect.Kind {
+			elements, err := parsePath(path)
+			Expect(err).To(BeNil())
+			return fieldKind(specType, elements)
+		}
+		Expect(kind("cmdLineArgs")).To(Equal(reflect.String))
+		Expect(kind("builtinJobFiles[0]")).To(Equal(reflect.String))
+		Expect(kind("builtinJobFiles")).To(Equal(reflect.Slice))
+		Expect(kind("image.name")).To(Equal(reflect.String))
+		Expect(kind("ttlSecondsAfterFinished")).To(Equal(reflect.Int32))
+		Expect(kind("suspend")).To(Equal(reflect.Bool))
+		Expect(kind("missing")).To(Equal(reflect.Invalid))
+		Expect(templateSpecType(scheme, "Unknown")).To(BeNil())
+	})
+
+	It("should create the missing objects of the path", func() {
+		spec := map[string]interface{}{}
+		Expect(setPath(spec, "volume.persistentVolumeClaimSpec.storageClassName", "ssd")).To(Succeed())
+		Expect(spec).To(Equal(map[string]interface{}{
+			"volume": map[string]interface{}{
+				"persistentVolumeClaimSpec": map[string]interface{}{
+					"storageClassName": "ssd",
+				},
+			},
+		}))
+	})
+
+	It("should set nested list items", func() {
+		spec := map[string]interface{}{
+			"jobs": []interface{}{[]interface{}{"a", "b"}},
+		}
+		Expect(setPath(spec, "jobs[0][1]", "c")).To(Succeed())
+		Expect(spec["jobs"]).To(Equal([]interface{}{[]interface{}{"a", "c"}}))
+	})
+
+	It("should reject list indices out of range", func() {
+		spec := map[string]interface{}{"jobs": []interface{}{"a"}}
+		Expect(setPath(spec, "jobs[1]", "b")).NotTo(Succeed())
+		Expect(setPath(spec, "missing[0]", "b")).NotTo(Succeed())
+	})
+
+	It("should reject fields of non-objects", func() {
+		spec := map[string]interface{}{"cmdLineArgs": "--size=1Gi"}
+		Expect(setPath(spec, "cmdLineArgs.size", "b")).NotTo(Succeed())
+	})
+
+	It("should reject malformed paths", func() {
+		for _, path := range []string{"", "a..b", "a[x]", "a[0", "[0]"} {
+			_, err := parsePath(path)
+			Expect(err).To(HaveOccurred(), path)
+		}
+	})
+
+	It("should parse JSON values", func() {
+		Expect(parseValue("8")).To(Equal(float64(8)))
+		Expect(parseValue("true")).To(Equal(true))
+		Expect(parseValue(`"8"`)).To(Equal("8"))
+		Expect(parseValue("4k")).To(Equal("4k"))
+	})
+})
@@ -0,0 +1,249 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkmatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+const (
+	// MatrixLabel is added to the benchmark CRs of a matrix with the name of the matrix
+	MatrixLabel = "kubestone.xridge.io/benchmark-matrix"
+
+	// PointLabel is added to the benchmark CRs of a matrix with the index of their point
+	PointLabel = "kubestone.xridge.io/matrix-point"
+
+	// maxPoints limits the size of the matrix, so its status fits in a single object
+	maxPoints = 256
+)
+
+// Reconciler provides fields from manager to reconciler
+type Reconciler struct {
+	K8S k8s.Access
+	Log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkmatrixes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkmatrixes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkmatrixes/finalizers,verbs=update
+
+// Reconcile creates the benchmark CRs of the points of the matrix, at
+// most parallelism of them running at the same time, and collects their
+// outcome into the status of the matrix
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	var cr perfv1alpha1.BenchmarkMatrix
+	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+
+	status := cr.GetBenchmarkStatus()
+	if status.Finished() {
+		return ctrl.Result{}, nil
+	}
+
+	// Validate on first entry
+	if status.Phase == "" {
+		status.ObservedGeneration = cr.Generation
+		if _, err := IsCrValid(&cr, r.K8S.Scheme); err != nil {
+			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed,
+				"CR validation failed: %v", err)
+			status.SetCondition(perfv1alpha1.BenchmarkConditionValid, corev1.ConditionFalse,
+				"ValidationFailed", err.Error())
+			status.MarkFailed("ValidationFailed", "CR validation failed: "+err.Error())
+
+			// Do not requeue invalid CRs
+			return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+		}
+
+		status.SetCondition(perfv1alpha1.BenchmarkConditionValid, corev1.ConditionTrue,
+			"ValidationSucceeded", "")
+		status.SetPhase(perfv1alpha1.BenchmarkRunning, "Matrix is running")
+	}
+
+	points, err := expand(&cr, r.K8S.Scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Points are created in order, so the running ones are counted
+	// before the next ones are created
+	running, finished, failed := 0, 0, 0
+	for index := range points {
+		name := benchmarkName(&cr, index)
+		if outcome := getOutcome(&cr.Status, name); outcome == nil {
+			if running >= parallelism(&cr) {
+				continue
+			}
+		} else if outcome.Finished() {
+			finished++
+			if outcome.Phase != perfv1alpha1.BenchmarkSucceeded {
+				failed++
+			}
+			continue
+		}
+
+		outcome, err := benchmark.RunFromTemplate(ctx, &r.K8S, &cr, &points[index].template,
+			metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cr.Namespace,
+				Labels: map[string]string{
+					MatrixLabel: cr.Name,
+					PointLabel:  strconv.Itoa(index),
+				},
+			})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		setOutcome(&cr.Status, perfv1alpha1.MatrixPointOutcome{
+			Values:           points[index].values,
+			BenchmarkOutcome: outcome,
+		})
+
+		if outcome.Finished() {
+			finished++
+			if outcome.Phase != perfv1alpha1.BenchmarkSucceeded {
+				failed++
+			}
+		} else {
+			running++
+		}
+	}
+
+	if finished < len(points) {
+		// The watches on the benchmark CRs trigger a new reconciliation
+		status.Message = fmt.Sprintf("%d of %d benchmarks finished", finished, len(points))
+		return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+	}
+
+	if failed > 0 {
+		message := fmt.Sprintf("%d of %d benchmark(s) failed", failed, len(points))
+		_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.Failed, message)
+		status.MarkFailed("BenchmarkFailed", message)
+	} else {
+		status.MarkSucceeded("All benchmarks of the matrix succeeded")
+	}
+
+	return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+}
+
+// IsCrValid validates the given CR and raises error if semantic errors detected.
+// The axis names have to be unique, the matrix can not exceed the maximum
+// number of points and every point has to describe a valid benchmark CR.
+func IsCrValid(cr *perfv1alpha1.BenchmarkMatrix, scheme *runtime.Scheme) (valid bool, err error) {
+	if len(cr.Spec.Axes) == 0 {
+		return false, errors.New("At least one axis must be specified in axes")
+	}
+
+	names := map[string]bool{}
+	for _, axis := range cr.Spec.Axes {
+		if axis.Name == "" {
+			return false, errors.New("The axis name must not be empty")
+		}
+		if names[axis.Name] {
+			return false, fmt.Errorf("The axis name '%s' is not unique", axis.Name)
+		}
+		names[axis.Name] = true
+
+		if len(axis.Values) == 0 {
+			return false, fmt.Errorf("The axis '%s' has no values", axis.Name)
+		}
+		if _, err := parsePath(axis.Path); err != nil {
+			return false, fmt.Errorf("The axis '%s' is invalid: %v", axis.Name, err)
+		}
+		// Avoid overflowing the point count
+		if len(axis.Values) > maxPoints {
+			return false, fmt.Errorf("The axis '%s' has more than %d values", axis.Name, maxPoints)
+		}
+	}
+
+	if count := pointCount(cr.Spec.Axes); count > maxPoints {
+		return false, fmt.Errorf("The matrix has %d points, at most %d are allowed", count, maxPoints)
+	}
+
+	points, err := expand(cr, scheme)
+	if err != nil {
+		return false, err
+	}
+	for index := range points {
+		name := benchmarkName(cr, index)
+		_, err := benchmark.NewFromTemplate(scheme, &points[index].template,
+			metav1.ObjectMeta{Name: name})
+		if err != nil {
+			return false, fmt.Errorf("The benchmark of %v is invalid: %v", points[index].values, err)
+		}
+	}
+
+	return true, nil
+}
+
+// benchmarkName returns the name of the benchmark CR of the point with the given index
+func benchmarkName(cr *perfv1alpha1.BenchmarkMatrix, index int) string {
+	return benchmark.ChildName(cr.Name, strconv.Itoa(index))
+}
+
+// parallelism returns the maximum number of benchmarks running at the same time
+func parallelism(cr *perfv1alpha1.BenchmarkMatrix) int {
+	if cr.Spec.Parallelism == nil || *cr.Spec.Parallelism < 1 {
+		return 1
+	}
+	return int(*cr.Spec.Parallelism)
+}
+
+// getOutcome returns the outcome of the benchmark CR with the given name
+// or nil if the benchmark CR was not created yet
+func getOutcome(status *perfv1alpha1.BenchmarkMatrixStatus, name string) *perfv1alpha1.MatrixPointOutcome {
+	for i := range status.Points {
+		if status.Points[i].Name == name {
+			return &status.Points[i]
+		}
+	}
+	return nil
+}
+
+// setOutcome adds or updates the outcome of a point in the status
+func setOutcome(status *perfv1alpha1.BenchmarkMatrixStatus, outcome perfv1alpha1.MatrixPointOutcome) {
+	if existing := getOutcome(status, outcome.Name); existing != nil {
+		*existing = outcome
+		return
+	}
+	status.Points = append(status.Points, outcome)
+}
+
+// SetupWithManager registers the Reconciler with the provided manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder, err := benchmark.OwnsBenchmarks(ctrl.NewControllerManagedBy(mgr).
+		For(&perfv1alpha1.BenchmarkMatrix{}), mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
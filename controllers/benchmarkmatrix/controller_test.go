@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkmatrix
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ksapi "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+func newMatrix() ksapi.BenchmarkMatrix {
+	return ksapi.BenchmarkMatrix{
+		ObjectMeta: metav1.ObjectMeta{Name: "sweep"},
+		Spec: ksapi.BenchmarkMatrixSpec{
+			Template: ksapi.BenchmarkTemplate{
+				Kind: "Fio",
+				Spec: runtime.RawExtension{Raw: []byte(`{"builtinJobFiles":["/jobs/rand-read.fio"]}`)},
+			},
+			Axes: []ksapi.MatrixAxis{
+				{Name: "job", Path: "builtinJobFiles[0]",
+					Values: []string{"/jobs/rand-read.fio", "/jobs/rand-write.fio"}},
+				{Name: "size", Path: "cmdLineArgs",
+					Values: []string{"--size=1Gi", "--size=10Gi"}},
+			},
+		},
+	}
+}
+
+var _ = Describe("BenchmarkMatrix validation", func() {
+	var scheme *runtime.Scheme
+	var cr ksapi.BenchmarkMatrix
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ksapi.AddToScheme(scheme)).To(Succeed())
+		cr = newMatrix()
+	})
+
+	It("should accept valid matrices", func() {
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeTrue())
+		Expect(err).To(BeNil())
+	})
+
+	It("should require at least one axis", func() {
+		cr.Spec.Axes = nil
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject duplicated axis names", func() {
+		cr.Spec.Axes[1].Name = "job"
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject axes without values", func() {
+		cr.Spec.Axes[1].Values = nil
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject matrices with too many points", func() {
+		var values []string
+		for i := 0; i < 200; i++ {
+			values = append(values, "--size="+strconv.Itoa(i)+"Gi")
+		}
+		cr.Spec.Axes[1].Values = values
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject values which do not fit the spec", func() {
+		cr.Spec.Axes[1].Path = "cmdLineArgz"
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject paths out of range", func() {
+		cr.Spec.Axes[0].Path = "builtinJobFiles[1]"
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("BenchmarkMatrix status", func() {
+	It("should default the parallelism", func() {
+		cr := newMatrix()
+		Expect(parallelism(&cr)).To(Equal(1))
+		four := int32(4)
+		cr.Spec.Parallelism = &four
+		Expect(parallelism(&cr)).To(Equal(4))
+	})
+
+	It("should add new outcomes and update the existing ones", func() {
+		status := ksapi.BenchmarkMatrixStatus{}
+		setOutcome(&status, ksapi.MatrixPointOutcome{
+			BenchmarkOutcome: ksapi.BenchmarkOutcome{Name: "sweep-0", Phase: ksapi.BenchmarkRunning}})
+		setOutcome(&status, ksapi.MatrixPointOutcome{
+			BenchmarkOutcome: ksapi.BenchmarkOutcome{Name: "sweep-0", Phase: ksapi.BenchmarkFailed}})
+
+		Expect(status.Points).To(HaveLen(1))
+		Expect(getOutcome(&status, "sweep-0").Phase).To(Equal(ksapi.BenchmarkFailed))
+		Expect(getOutcome(&status, "sweep-1")).To(BeNil())
+	})
+})
@@ -0,0 +1,251 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkschedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+const (
+	// ScheduleLabel is added to the benchmark CRs of a schedule with
+	// the name of the schedule
+	ScheduleLabel = "kubestone.xridge.io/benchmark-schedule"
+
+	// ScheduledTimeLabel is added to the benchmark CRs of a schedule with
+	// the time (in seconds since the Unix epoch) the benchmark was due,
+	// so the results of the schedule can be trended over time
+	ScheduledTimeLabel = "kubestone.xridge.io/scheduled-time"
+
+	// defaultHistoryLimit is the number of finished benchmarks retained
+	// when the history limit is not specified
+	defaultHistoryLimit = 10
+)
+
+// Reconciler provides fields from manager to reconciler
+type Reconciler struct {
+	K8S k8s.Access
+	Log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkschedules/finalizers,verbs=update
+
+// Reconcile creates the benchmark CR of the schedule when it is due and
+// removes the finished benchmark CRs exceeding the history limit
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	now := time.Now()
+
+	var cr perfv1alpha1.BenchmarkSchedule
+	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+
+	if _, err := IsCrValid(&cr, r.K8S.Scheme); err != nil {
+		message := "CR validation failed: " + err.Error()
+		if cr.Status.Message == message {
+			return ctrl.Result{}, nil
+		}
+		_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed, message)
+		cr.Status.Message = message
+		cr.Status.NextScheduleTime = nil
+
+		// Do not requeue invalid CRs, the spec update triggers a new reconciliation
+		return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+	}
+	cr.Status.Message = ""
+
+	crs, err := benchmark.ListControlled(ctx, &r.K8S, &cr, cr.Spec.Template.Kind,
+		map[string]string{ScheduleLabel: cr.Name})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var active, finished []benchmark.Object
+	for _, benchmarkCR := range crs {
+		if benchmarkCR.GetBenchmarkStatus().Finished() {
+			finished = append(finished, benchmarkCR)
+		} else {
+			active = append(active, benchmarkCR)
+		}
+	}
+
+	for _, benchmarkCR := range expired(finished, historyLimit(&cr)) {
+		if err := r.K8S.DeleteObject(ctx, benchmarkCR, &cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if cr.Spec.Suspend {
+		cr.Status.Active = references(active, cr.Spec.Template.Kind)
+		cr.Status.NextScheduleTime = nil
+		return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+	}
+
+	schedule, _ := cron.ParseStandard(cr.Spec.Schedule)
+	since := cr.CreationTimestamp.Time
+	if cr.Status.LastScheduleTime != nil {
+		since = cr.Status.LastScheduleTime.Time
+	}
+	due, next := scheduleTimes(schedule, since, now)
+	cr.Status.NextScheduleTime = &metav1.Time{Time: next}
+
+	if due != nil {
+		cr.Status.LastScheduleTime = &metav1.Time{Time: *due}
+		active, err = r.run(ctx, &cr, *due, active)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	cr.Status.Active = references(active, cr.Spec.Template.Kind)
+	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// run creates the benchmark CR due at the given time according to the
+// concurrency policy of the schedule and returns the active benchmark CRs
+func (r *Reconciler) run(ctx context.Context, cr *perfv1alpha1.BenchmarkSchedule, due time.Time,
+	active []benchmark.Object) ([]benchmark.Object, error) {
+	switch cr.Spec.ConcurrencyPolicy {
+	case perfv1alpha1.AllowConcurrent:
+	case perfv1alpha1.ReplaceConcurrent:
+		for _, benchmarkCR := range active {
+			if err := r.K8S.DeleteObject(ctx, benchmarkCR, cr); err != nil {
+				return nil, err
+			}
+		}
+		active = nil
+	default:
+		if len(active) > 0 {
+			_ = r.K8S.RecordEventf(cr, corev1.EventTypeNormal, k8s.Skipped,
+				"Skipped benchmark due at %v: %v is still running",
+				due.Format(time.RFC3339), active[0].GetName())
+			return active, nil
+		}
+	}
+
+	benchmarkCR, err := benchmark.NewFromTemplate(r.K8S.Scheme, &cr.Spec.Template, metav1.ObjectMeta{
+		Name:      benchmarkName(cr, due),
+		Namespace: cr.Namespace,
+		Labels: map[string]string{
+			ScheduleLabel:      cr.Name,
+			ScheduledTimeLabel: strconv.FormatInt(due.Unix(), 10),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.K8S.CreateWithReference(ctx, benchmarkCR, cr); err != nil {
+		return nil, err
+	}
+	return append(active, benchmarkCR), nil
+}
+
+// IsCrValid validates the given CR and raises error if semantic errors detected.
+// The schedule has to be in Cron format and the template has to describe
+// a valid benchmark CR.
+func IsCrValid(cr *perfv1alpha1.BenchmarkSchedule, scheme *runtime.Scheme) (valid bool, err error) {
+	if _, err := cron.ParseStandard(cr.Spec.Schedule); err != nil {
+		return false, fmt.Errorf("The schedule '%s' is invalid: %v", cr.Spec.Schedule, err)
+	}
+
+	_, err = benchmark.NewFromTemplate(scheme, &cr.Spec.Template,
+		metav1.ObjectMeta{Name: cr.Name})
+	if err != nil {
+		return false, fmt.Errorf("The template is invalid: %v", err)
+	}
+
+	return true, nil
+}
+
+// scheduleTimes returns the most recent time a benchmark was due since the
+// given time (nil if none) and the next time a benchmark is due. Missed
+// schedules (e.g. while the operator was not running) are not caught up.
+func scheduleTimes(schedule cron.Schedule, since, now time.Time) (due *time.Time, next time.Time) {
+	for next = schedule.Next(since); !next.After(now); next = schedule.Next(next) {
+		t := next
+		due = &t
+	}
+	return due, next
+}
+
+// benchmarkName returns the name of the benchmark CR due at the given time
+func benchmarkName(cr *perfv1alpha1.BenchmarkSchedule, due time.Time) string {
+	return benchmark.ChildName(cr.Name, strconv.FormatInt(due.Unix(), 10))
+}
+
+// historyLimit returns the number of finished benchmarks to retain
+func historyLimit(cr *perfv1alpha1.BenchmarkSchedule) int {
+	if cr.Spec.HistoryLimit == nil {
+		return defaultHistoryLimit
+	}
+	return int(*cr.Spec.HistoryLimit)
+}
+
+// expired returns the oldest finished benchmark CRs exceeding the limit
+func expired(finished []benchmark.Object, limit int) []benchmark.Object {
+	if len(finished) <= limit {
+		return nil
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].GetCreationTimestamp().Time.Before(finished[j].GetCreationTimestamp().Time)
+	})
+	return finished[:len(finished)-limit]
+}
+
+// references returns the object references of the benchmark CRs of the given kind
+func references(crs []benchmark.Object, kind string) []corev1.ObjectReference {
+	var refs []corev1.ObjectReference
+	for _, cr := range crs {
+		refs = append(refs, corev1.ObjectReference{
+			APIVersion: perfv1alpha1.GroupVersion.String(),
+			Kind:       kind,
+			Namespace:  cr.GetNamespace(),
+			Name:       cr.GetName(),
+			UID:        cr.GetUID(),
+		})
+	}
+	return refs
+}
+
+// SetupWithManager registers the Reconciler with the provided manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder, err := benchmark.OwnsBenchmarks(ctrl.NewControllerManagedBy(mgr).
+		For(&perfv1alpha1.BenchmarkSchedule{}), mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
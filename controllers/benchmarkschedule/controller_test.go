@@ -0,0 +1,142 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarkschedule
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ksapi "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
+)
+
+var _ = Describe("BenchmarkSchedule validation", func() {
+	var scheme *runtime.Scheme
+	var cr ksapi.BenchmarkSchedule
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ksapi.AddToScheme(scheme)).To(Succeed())
+		cr = ksapi.BenchmarkSchedule{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+			Spec: ksapi.BenchmarkScheduleSpec{
+				Schedule: "0 2 * * *",
+				Template: ksapi.BenchmarkTemplate{
+					Kind: "Sysbench",
+					Spec: runtime.RawExtension{Raw: []byte(`{"testName":"cpu"}`)},
+				},
+			},
+		}
+	})
+
+	It("should accept valid schedules", func() {
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeTrue())
+		Expect(err).To(BeNil())
+	})
+
+	It("should reject schedules which are not in Cron format", func() {
+		cr.Spec.Schedule = "every night"
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid templates", func() {
+		cr.Spec.Template.Spec.Raw = []byte(`{"testName":"cpu","thread":1}`)
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("BenchmarkSchedule timing", func() {
+	var schedule cron.Schedule
+	var since time.Time
+
+	BeforeEach(func() {
+		var err error
+		schedule, err = cron.ParseStandard("0 2 * * *")
+		Expect(err).To(BeNil())
+		since = time.Date(2019, 11, 1, 3, 0, 0, 0, time.Local)
+	})
+
+	It("should not be due before the next schedule", func() {
+		due, next := scheduleTimes(schedule, since, since.Add(time.Hour))
+		Expect(due).To(BeNil())
+		Expect(next).To(Equal(time.Date(2019, 11, 2, 2, 0, 0, 0, time.Local)))
+	})
+
+	It("should be due once the schedule is reached", func() {
+		due, next := scheduleTimes(schedule, since, since.Add(23*time.Hour))
+		Expect(due).NotTo(BeNil())
+		Expect(*due).To(Equal(time.Date(2019, 11, 2, 2, 0, 0, 0, time.Local)))
+		Expect(next).To(Equal(time.Date(2019, 11, 3, 2, 0, 0, 0, time.Local)))
+	})
+
+	It("should only be due for the most recent missed schedule", func() {
+		due, next := scheduleTimes(schedule, since, since.Add(3*24*time.Hour))
+		Expect(due).NotTo(BeNil())
+		Expect(*due).To(Equal(time.Date(2019, 11, 4, 2, 0, 0, 0, time.Local)))
+		Expect(next).To(Equal(time.Date(2019, 11, 5, 2, 0, 0, 0, time.Local)))
+	})
+
+	It("should name the benchmarks after the scheduled time", func() {
+		cr := ksapi.BenchmarkSchedule{ObjectMeta: metav1.ObjectMeta{Name: "nightly"}}
+		Expect(benchmarkName(&cr, time.Unix(1572573600, 0))).To(Equal("nightly-1572573600"))
+	})
+})
+
+var _ = Describe("BenchmarkSchedule history", func() {
+	newSysbench := func(name string, created time.Time) benchmark.Object {
+		return &ksapi.Sysbench{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.Time{Time: created},
+		}}
+	}
+
+	It("should default the history limit", func() {
+		cr := ksapi.BenchmarkSchedule{}
+		Expect(historyLimit(&cr)).To(Equal(defaultHistoryLimit))
+		limit := int32(0)
+		cr.Spec.HistoryLimit = &limit
+		Expect(historyLimit(&cr)).To(Equal(0))
+	})
+
+	It("should expire the oldest benchmarks exceeding the limit", func() {
+		now := time.Now()
+		finished := []benchmark.Object{
+			newSysbench("second", now.Add(-2*time.Hour)),
+			newSysbench("third", now.Add(-time.Hour)),
+			newSysbench("first", now.Add(-3*time.Hour)),
+		}
+		expiredCRs := expired(finished, 1)
+		Expect(expiredCRs).To(HaveLen(2))
+		Expect(expiredCRs[0].GetName()).To(Equal("first"))
+		Expect(expiredCRs[1].GetName()).To(Equal("second"))
+	})
+
+	It("should not expire benchmarks within the limit", func() {
+		finished := []benchmark.Object{newSysbench("first", time.Now())}
+		Expect(expired(finished, 1)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,216 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarksuite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// SuiteLabel is added to the benchmark CRs of a suite with the name of the suite
+const SuiteLabel = "kubestone.xridge.io/benchmark-suite"
+
+// Reconciler provides fields from manager to reconciler
+type Reconciler struct {
+	K8S k8s.Access
+	Log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarksuites,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarksuites/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarksuites/finalizers,verbs=update
+
+// Reconcile executes the steps of the suite one after the other: the
+// benchmark CRs of the current step are created and the suite proceeds
+// to the next step once all of them are finished.
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	var cr perfv1alpha1.BenchmarkSuite
+	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+
+	status := cr.GetBenchmarkStatus()
+	if status.Finished() {
+		return ctrl.Result{}, nil
+	}
+
+	// Validate on first entry
+	if status.Phase == "" {
+		status.ObservedGeneration = cr.Generation
+		if _, err := IsCrValid(&cr, r.K8S.Scheme); err != nil {
+			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed,
+				"CR validation failed: %v", err)
+			status.SetCondition(perfv1alpha1.BenchmarkConditionValid, corev1.ConditionFalse,
+				"ValidationFailed", err.Error())
+			status.MarkFailed("ValidationFailed", "CR validation failed: "+err.Error())
+
+			// Do not requeue invalid CRs
+			return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+		}
+
+		status.SetCondition(perfv1alpha1.BenchmarkConditionValid, corev1.ConditionTrue,
+			"ValidationSucceeded", "")
+		status.SetPhase(perfv1alpha1.BenchmarkRunning, "Suite is running")
+	}
+
+	for ; int(cr.Status.CurrentStep) < len(cr.Spec.Steps); cr.Status.CurrentStep++ {
+		index := int(cr.Status.CurrentStep)
+		step := &cr.Spec.Steps[index]
+		name := stepName(step, index)
+
+		finished, failed := true, 0
+		for i := range step.Benchmarks {
+			suiteBenchmark := &step.Benchmarks[i]
+			outcome, err := benchmark.RunFromTemplate(ctx, &r.K8S, &cr,
+				&suiteBenchmark.BenchmarkTemplate, metav1.ObjectMeta{
+					Name:      benchmarkName(&cr, suiteBenchmark),
+					Namespace: cr.Namespace,
+					Labels:    map[string]string{SuiteLabel: cr.Name},
+				})
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			setOutcome(&cr.Status, perfv1alpha1.SuiteBenchmarkOutcome{
+				Step:             name,
+				BenchmarkOutcome: outcome,
+			})
+
+			if !outcome.Finished() {
+				finished = false
+			} else if outcome.Phase != perfv1alpha1.BenchmarkSucceeded {
+				failed++
+			}
+		}
+
+		if !finished {
+			// The watches on the benchmark CRs trigger a new reconciliation
+			status.Message = fmt.Sprintf("Executing step %s", name)
+			return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+		}
+
+		if failed > 0 && !cr.Spec.ContinueOnFailure {
+			message := fmt.Sprintf("%d benchmark(s) of step %s failed", failed, name)
+			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.Failed, message)
+			status.MarkFailed("BenchmarkFailed", message)
+			return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+		}
+	}
+
+	failed := 0
+	for _, outcome := range cr.Status.Benchmarks {
+		if outcome.Phase != perfv1alpha1.BenchmarkSucceeded {
+			failed++
+		}
+	}
+	if failed > 0 {
+		message := fmt.Sprintf("%d benchmark(s) failed", failed)
+		_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.Failed, message)
+		status.MarkFailed("BenchmarkFailed", message)
+	} else {
+		status.MarkSucceeded("All benchmarks of the suite succeeded")
+	}
+
+	return ctrl.Result{}, r.K8S.Client.Status().Update(ctx, &cr)
+}
+
+// IsCrValid validates the given CR and raises error if semantic errors detected.
+// The benchmark names have to be unique and the templates have to describe
+// valid benchmark CRs.
+func IsCrValid(cr *perfv1alpha1.BenchmarkSuite, scheme *runtime.Scheme) (valid bool, err error) {
+	if len(cr.Spec.Steps) == 0 {
+		return false, errors.New("At least one step must be specified in steps")
+	}
+
+	names := map[string]bool{}
+	for index := range cr.Spec.Steps {
+		step := &cr.Spec.Steps[index]
+		if len(step.Benchmarks) == 0 {
+			return false, fmt.Errorf("Step %s has no benchmarks", stepName(step, index))
+		}
+
+		for i := range step.Benchmarks {
+			suiteBenchmark := &step.Benchmarks[i]
+			if msgs := validation.IsDNS1123Label(suiteBenchmark.Name); len(msgs) > 0 {
+				return false, fmt.Errorf("The benchmark name '%s' is invalid: %s",
+					suiteBenchmark.Name, strings.Join(msgs, ", "))
+			}
+			if names[suiteBenchmark.Name] {
+				return false, fmt.Errorf("The benchmark name '%s' is not unique", suiteBenchmark.Name)
+			}
+			names[suiteBenchmark.Name] = true
+
+			name := benchmarkName(cr, suiteBenchmark)
+			_, err := benchmark.NewFromTemplate(scheme, &suiteBenchmark.BenchmarkTemplate,
+				metav1.ObjectMeta{Name: name})
+			if err != nil {
+				return false, fmt.Errorf("The benchmark '%s' is invalid: %v", suiteBenchmark.Name, err)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// benchmarkName returns the name of the benchmark CR created by the suite
+func benchmarkName(cr *perfv1alpha1.BenchmarkSuite, suiteBenchmark *perfv1alpha1.SuiteBenchmark) string {
+	return benchmark.ChildName(cr.Name, suiteBenchmark.Name)
+}
+
+// stepName returns the name of the step or its index if the step is unnamed
+func stepName(step *perfv1alpha1.BenchmarkSuiteStep, index int) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return strconv.Itoa(index)
+}
+
+// setOutcome adds or updates the outcome of a benchmark in the status
+func setOutcome(status *perfv1alpha1.BenchmarkSuiteStatus, outcome perfv1alpha1.SuiteBenchmarkOutcome) {
+	for i := range status.Benchmarks {
+		if status.Benchmarks[i].Name == outcome.Name {
+			status.Benchmarks[i] = outcome
+			return
+		}
+	}
+	status.Benchmarks = append(status.Benchmarks, outcome)
+}
+
+// SetupWithManager registers the Reconciler with the provided manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder, err := benchmark.OwnsBenchmarks(ctrl.NewControllerManagedBy(mgr).
+		For(&perfv1alpha1.BenchmarkSuite{}), mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
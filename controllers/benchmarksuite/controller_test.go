@@ -0,0 +1,131 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmarksuite
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	ksapi "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+func sysbenchTemplate() ksapi.BenchmarkTemplate {
+	return ksapi.BenchmarkTemplate{
+		Kind: "Sysbench",
+		Spec: runtime.RawExtension{Raw: []byte(`{"testName":"cpu"}`)},
+	}
+}
+
+var _ = Describe("BenchmarkSuite validation", func() {
+	var scheme *runtime.Scheme
+	var cr ksapi.BenchmarkSuite
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(ksapi.AddToScheme(scheme)).To(Succeed())
+		cr = ksapi.BenchmarkSuite{
+			ObjectMeta: metav1.ObjectMeta{Name: "suite"},
+			Spec: ksapi.BenchmarkSuiteSpec{
+				Steps: []ksapi.BenchmarkSuiteStep{
+					{Name: "cpu", Benchmarks: []ksapi.SuiteBenchmark{
+						{Name: "cpu-1", BenchmarkTemplate: sysbenchTemplate()},
+						{Name: "cpu-2", BenchmarkTemplate: sysbenchTemplate()},
+					}},
+					{Benchmarks: []ksapi.SuiteBenchmark{
+						{Name: "cpu-3", BenchmarkTemplate: sysbenchTemplate()},
+					}},
+				},
+			},
+		}
+	})
+
+	It("should accept valid suites", func() {
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeTrue())
+		Expect(err).To(BeNil())
+	})
+
+	It("should require at least one step", func() {
+		cr.Spec.Steps = nil
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should require at least one benchmark in every step", func() {
+		cr.Spec.Steps[1].Benchmarks = nil
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject benchmark names which are not DNS-safe", func() {
+		cr.Spec.Steps[0].Benchmarks[0].Name = "CPU_1"
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject benchmark names which are not unique across the steps", func() {
+		cr.Spec.Steps[1].Benchmarks[0].Name = "cpu-1"
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should shorten the benchmark CR names of suites with long names", func() {
+		cr.Name = strings.Repeat("s", 250)
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeTrue())
+		Expect(err).To(BeNil())
+		Expect(len(benchmarkName(&cr, &cr.Spec.Steps[0].Benchmarks[0]))).To(
+			BeNumerically("<=", validation.DNS1123LabelMaxLength))
+	})
+
+	It("should reject invalid benchmark specs", func() {
+		cr.Spec.Steps[1].Benchmarks[0].Spec.Raw = []byte(`{"testName":"cpu","thread":1}`)
+		valid, err := IsCrValid(&cr, scheme)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("BenchmarkSuite status", func() {
+	It("should name unnamed steps by their index", func() {
+		Expect(stepName(&ksapi.BenchmarkSuiteStep{Name: "disk"}, 1)).To(Equal("disk"))
+		Expect(stepName(&ksapi.BenchmarkSuiteStep{}, 1)).To(Equal("1"))
+	})
+
+	It("should add new outcomes and update the existing ones", func() {
+		status := ksapi.BenchmarkSuiteStatus{}
+		setOutcome(&status, ksapi.SuiteBenchmarkOutcome{Step: "0",
+			BenchmarkOutcome: ksapi.BenchmarkOutcome{Name: "a", Phase: ksapi.BenchmarkRunning}})
+		setOutcome(&status, ksapi.SuiteBenchmarkOutcome{Step: "0",
+			BenchmarkOutcome: ksapi.BenchmarkOutcome{Name: "b", Phase: ksapi.BenchmarkRunning}})
+		setOutcome(&status, ksapi.SuiteBenchmarkOutcome{Step: "0",
+			BenchmarkOutcome: ksapi.BenchmarkOutcome{Name: "a", Phase: ksapi.BenchmarkSucceeded}})
+
+		Expect(status.Benchmarks).To(HaveLen(2))
+		Expect(status.Benchmarks[0].Phase).To(Equal(ksapi.BenchmarkSucceeded))
+		Expect(status.Benchmarks[1].Phase).To(Equal(ksapi.BenchmarkRunning))
+	})
+})
@@ -17,22 +17,28 @@ limitations under the License.
 package drill
 
 import (
-	"context"
-
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	corev1 "k8s.io/api/core/v1"
-
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
 )
 
 // Reconciler reconciles a Drill object
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=drills,verbs=get;list;watch;create;update;patch;delete
@@ -41,73 +47,56 @@ type Reconciler struct {
 
 // Reconcile creates drill job for the Custom Resources
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-
-	var cr perfv1alpha1.Drill
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-
-	// Run to one completion
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
-
-	// Validate on first entry
-	if !cr.Status.Completed && !cr.Status.Running {
-		if valid, err := IsCrValid(&cr); !valid {
-			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed,
-				"CR validation failed: %v", err)
-
-			// Do not requeue invalid CRs
-			return ctrl.Result{}, nil
-		}
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("drill", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// NewObject returns an empty Drill CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.Drill{}
+}
 
-	configMap := NewConfigMap(&cr)
-	if err := r.K8S.CreateWithReference(ctx, configMap, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Default fills the unset images of the Drill CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.Drill)
+	r.Images.Default("drill", &cr.Spec.Image)
+}
 
-	job := NewJob(&cr, configMap)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Validate checks the Drill CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.Drill))
+	return err
+}
 
-	// Check if finished
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
-	}
+// Steps returns the ConfigMap and the Job of the drill benchmark
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.Drill)
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+	configMap := NewConfigMap(cr)
+	return []benchmark.Step{{Objects: []metav1.Object{configMap, NewJob(cr, configMap)}}}, nil
+}
 
-	return ctrl.Result{}, nil
+// CollectResults parses the output of the drill job
+func (r *Reconciler) CollectResults(object benchmark.Object, job *batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error) {
+	return results.Collect(&r.K8S, types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, results.ParseDrill)
 }
 
 // SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.Drill{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-drill,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=drills,verbs=create;update,versions=v1alpha1,name=vdrill.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of Drill CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-drill", r)
+}
@@ -67,6 +67,7 @@ func NewJob(cr *perfv1alpha1.Drill, configMap *corev1.ConfigMap) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Command = []string{"/bin/sh", "-xc"}
 	job.Spec.Template.Spec.Containers[0].Args = []string{command}
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
 
@@ -18,116 +18,153 @@ package esrally
 
 import (
 	"context"
-	"github.com/xridge/kubestone/api/v1alpha1"
-	"github.com/xridge/kubestone/pkg/k8s"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
 
-	"github.com/go-logr/logr"
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
-	ctrl "sigs.k8s.io/controller-runtime"
+	"github.com/xridge/kubestone/pkg/benchmark"
+	"github.com/xridge/kubestone/pkg/k8s"
 )
 
-// EsRallyReconciler reconciles a EsRally object
+// Reconciler reconciles a EsRally object
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;create;delete
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;create;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;create;delete;watch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;create;delete;watch
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=esrallies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=esrallies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=esrallies/finalizers,verbs=update
 
+// Reconcile EsRally Benchmark Requests by creating:
+//   - the esrally coordinator job
+//   - the rally worker StatefulSet and Service
+//
+// The StatefulSet is created once the pod of the coordinator job has
+// an IP address, the benchmark runs when the StatefulSet is ready. The
+// StatefulSet and the Service are removed once the coordinator job is
+// finished.
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-	logger := r.Log.WithValues("esrally", req.NamespacedName)
-
-	var cr perfv1alpha1.EsRally
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-
-	// Return if its completed
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
-
-	namespaceName := types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	}
-
-	if cr.Spec.Image == (v1alpha1.ImageSpec{}) {
-		cr.Spec.Image = v1alpha1.ImageSpec{
-			Name:       "diamantisolutions/esrally:kubestone",
-			PullPolicy: "Always",
-		}
-	}
-
-	// If its not running, create job and mark it as running
-	if !cr.Status.Running {
-		return esRallyJobHandler(cr, r, ctx, namespaceName)
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("esrally", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	// Grab the job pod to pass to statefulset
-	pods, err := r.K8S.GetJobPods(namespaceName)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if len(pods.Items) == 0 || pods.Items[0].Status.PodIP == "" {
-		logger.Info("waiting for pod ip")
-		return ctrl.Result{Requeue: true}, nil
-	}
+// NewObject returns an empty EsRally CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.EsRally{}
+}
 
-	// Deploy statefulset
-	if !cr.Status.Deployed {
-		return esRallyDeployHandler(cr, r, ctx, pods.Items[0].Status.PodIP)
-	}
+// Default fills the unset images of the EsRally CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.EsRally)
+	r.Images.Default("esrally", &cr.Spec.Image)
+}
 
-	_, ready, _ := r.K8S.IsStatefulSetReady(namespaceName)
-	if !ready {
-		// We need to wait for the StatefulSet to be ready, so requeue
-		return ctrl.Result{Requeue: true}, nil
-	}
+// Validate checks the EsRally CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.EsRally))
+	return err
+}
 
-	jobFinished, err := r.K8S.IsJobFinished(namespaceName)
-	if err != nil {
-		return ctrl.Result{}, err
+// ValidateReferences checks that the referred password Secret exists
+func (r *Reconciler) ValidateReferences(object benchmark.Object) error {
+	cr := object.(*perfv1alpha1.EsRally)
+	if cr.Spec.Security != nil && cr.Spec.Security.BasicAuth != nil {
+		return r.K8S.CheckSecretKeyRef(cr.Namespace, cr.Spec.Security.BasicAuth.PasswordSecretRef)
 	}
+	return nil
+}
 
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
+// Steps returns the coordinator job, which has to get an IP address
+// before the worker StatefulSet and Service are created
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.EsRally)
+	namespaceName := types.NamespacedName{
+		Namespace: cr.Namespace,
+		Name:      cr.Name,
 	}
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
+	coordinatorIP := ""
+	job := NewJob(cr)
+	return []benchmark.Step{
+		{
+			Objects: []metav1.Object{job},
+			Ready: func() (bool, error) {
+				// Grab the job pod to pass to statefulset. The job is
+				// renamed by the engine for the reruns.
+				pods, err := r.K8S.GetJobPods(types.NamespacedName{
+					Namespace: job.Namespace,
+					Name:      job.Name,
+				})
+				if err != nil || pods == nil {
+					return false, err
+				}
+				if len(pods.Items) == 0 || pods.Items[0].Status.PodIP == "" {
+					r.Log.Info("waiting for pod ip", "esrally", namespaceName)
+					return false, nil
+				}
+				coordinatorIP = pods.Items[0].Status.PodIP
+				return true, nil
+			},
+			// Pods are owned by the Job, not by the CR, so they are polled
+			Poll: true,
+		},
+		{
+			Build: func() ([]metav1.Object, error) {
+				statefulSet, err := NewStatefulSet(cr, coordinatorIP)
+				if err != nil {
+					return nil, err
+				}
+				return []metav1.Object{newService(cr, statefulSet), statefulSet}, nil
+			},
+			Ready: func() (bool, error) {
+				_, ready, _ := r.K8S.IsStatefulSetReady(namespaceName)
+				if ready {
+					cr.Status.SetCondition(perfv1alpha1.BenchmarkConditionDeployed, corev1.ConditionTrue,
+						"StatefulSetReady", "Rally worker StatefulSet is ready")
+				}
+				return ready, nil
+			},
+		},
+	}, nil
+}
 
-	cr.Status.Running = false
-	cr.Status.Completed = true
+// Cleanup removes the rally worker StatefulSet and Service once the
+// coordinator job is finished
+func (r *Reconciler) Cleanup(ctx context.Context, object benchmark.Object) error {
+	cr := object.(*perfv1alpha1.EsRally)
+	objectMeta := metav1.ObjectMeta{Name: cr.Name, Namespace: cr.Namespace}
 
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
+	if err := r.K8S.DeleteObject(ctx, &corev1.Service{ObjectMeta: objectMeta}, cr); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	return r.K8S.DeleteObject(ctx, &appsv1.StatefulSet{ObjectMeta: objectMeta}, cr)
 }
 
-func esRallyDeployHandler(cr perfv1alpha1.EsRally, r *Reconciler, ctx context.Context, ip string) (ctrl.Result, error) {
-	statefulSet, sError := NewStatefulSet(&cr, ip)
-	if sError != nil {
-		return ctrl.Result{}, sError
-	}
-
-	// Create service
-	service := corev1.Service{
+func newService(cr *perfv1alpha1.EsRally, statefulSet *appsv1.StatefulSet) *corev1.Service {
+	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name,
 			Namespace: cr.Namespace,
@@ -138,46 +175,22 @@ func esRallyDeployHandler(cr perfv1alpha1.EsRally, r *Reconciler, ctx context.Co
 			},
 			Selector: statefulSet.Spec.Selector.MatchLabels,
 		},
-		Status: corev1.ServiceStatus{},
-	}
-
-	// Create service
-	if err := r.K8S.CreateWithReference(ctx, &service, &cr); err != nil {
-		return ctrl.Result{}, err
 	}
-
-	// Create StatefulSet
-	if err := r.K8S.CreateWithReference(ctx, statefulSet, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// set Deployed as true
-	cr.Status.Deployed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// We need to wait for the StatefulSet to be ready, so requeue
-	return ctrl.Result{Requeue: true}, nil
-}
-
-func esRallyJobHandler(cr perfv1alpha1.EsRally, r *Reconciler, ctx context.Context, namespaceName types.NamespacedName) (ctrl.Result, error) {
-	job := NewJob(&cr)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Mark it as running
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	return ctrl.Result{Requeue: true}, nil
 }
 
+// SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.EsRally{}).
+		Owns(&batchv1.Job{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.Service{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-esrally,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=esrallies,verbs=create;update,versions=v1alpha1,name=vesrally.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of EsRally CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-esrally", r)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package esrally
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+var _ = Describe("EsRally cleanup", func() {
+	It("should remove the rally worker StatefulSet and Service", func() {
+		cr := &perfv1alpha1.EsRally{
+			ObjectMeta: metav1.ObjectMeta{Name: "esrally-sample", Namespace: "kubestone"},
+			Spec: perfv1alpha1.EsRallySpec{},
+		}
+		objectMeta := metav1.ObjectMeta{Name: cr.Name, Namespace: cr.Namespace}
+		scheme := runtime.NewScheme()
+		Expect(k8sscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(perfv1alpha1.AddToScheme(scheme)).To(Succeed())
+		reconciler := Reconciler{K8S: k8s.Access{
+			Client: fake.NewFakeClientWithScheme(scheme, cr,
+				&appsv1.StatefulSet{ObjectMeta: objectMeta}, &corev1.Service{ObjectMeta: objectMeta}),
+			Scheme:        scheme,
+			EventRecorder: record.NewFakeRecorder(10),
+		}}
+
+		Expect(reconciler.Cleanup(context.Background(), cr)).To(Succeed())
+
+		name := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+		err := reconciler.K8S.Client.Get(context.Background(), name, &appsv1.StatefulSet{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		err = reconciler.K8S.Client.Get(context.Background(), name, &corev1.Service{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
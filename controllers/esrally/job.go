@@ -17,6 +17,7 @@ limitations under the License.
 package esrally
 
 import (
+	"errors"
 	"fmt"
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 	"github.com/xridge/kubestone/pkg/k8s"
@@ -26,6 +27,10 @@ import (
 	"strings"
 )
 
+// basicAuthPasswordEnv is the environment variable holding the
+// basic auth password referred from a Secret
+const basicAuthPasswordEnv = "ES_BASIC_AUTH_PASSWORD"
+
 func NewJob(cr *perfv1alpha1.EsRally) *batchv1.Job {
 	objectMeta := metav1.ObjectMeta{
 		Name:      cr.Name,
@@ -59,9 +64,21 @@ func NewJob(cr *perfv1alpha1.EsRally) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Args = esJobContainer.Args
 	job.Spec.Template.Spec.Containers[0].Env = esJobContainer.Env
 
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
 
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For esrally it checks that the basic auth password is specified only once
+func IsCrValid(cr *perfv1alpha1.EsRally) (valid bool, err error) {
+	if cr.Spec.Security != nil && cr.Spec.Security.BasicAuth != nil &&
+		cr.Spec.Security.BasicAuth.Password != "" && cr.Spec.Security.BasicAuth.PasswordSecretRef != nil {
+		return false, errors.New("Only one of basicAuth.password and basicAuth.passwordSecretRef can be specified")
+	}
+
+	return true, nil
+}
+
 func CreateEsRallyCmd(spec *perfv1alpha1.EsRallySpec, objectMeta *metav1.ObjectMeta) []string {
 	var cmdArgs []string
 
@@ -94,7 +111,13 @@ func CreateEsRallyCmd(spec *perfv1alpha1.EsRallySpec, objectMeta *metav1.ObjectM
 
 		if spec.Security.BasicAuth != nil {
 			clientOptions = append(clientOptions, fmt.Sprintf("basic_auth_user:'%s'", spec.Security.BasicAuth.Username))
-			clientOptions = append(clientOptions, fmt.Sprintf("basic_auth_password:'%s'", spec.Security.BasicAuth.Password))
+			password := spec.Security.BasicAuth.Password
+			if spec.Security.BasicAuth.PasswordSecretRef != nil {
+				// Expanded by Kubernetes in the arguments of the container, so
+				// it does not depend on a shell executing the command
+				password = "$(" + basicAuthPasswordEnv + ")"
+			}
+			clientOptions = append(clientOptions, fmt.Sprintf("basic_auth_password:'%s'", password))
 		}
 	}
 
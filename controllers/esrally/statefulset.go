@@ -18,6 +18,7 @@ package esrally
 
 import (
 	"github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -130,9 +131,10 @@ func NewStatefulSet(cr *v1alpha1.EsRally, coordinatorHostname string) (*v1.State
 					Annotations: cr.Spec.PodConfig.Annotations,
 				},
 				Spec: corev1.PodSpec{
-					NodeSelector: cr.Spec.PodConfig.PodScheduling.NodeSelector,
-					Affinity:     cr.Spec.PodConfig.PodScheduling.Affinity,
-					Tolerations:  cr.Spec.PodConfig.PodScheduling.Tolerations,
+					ImagePullSecrets: k8s.ImagePullSecrets(cr.Spec.Image),
+					NodeSelector:     cr.Spec.PodConfig.PodScheduling.NodeSelector,
+					Affinity:         cr.Spec.PodConfig.PodScheduling.Affinity,
+					Tolerations:      cr.Spec.PodConfig.PodScheduling.Tolerations,
 					InitContainers: []corev1.Container{
 						initContainer,
 					},
@@ -145,11 +147,12 @@ func NewStatefulSet(cr *v1alpha1.EsRally, coordinatorHostname string) (*v1.State
 		},
 	}
 
+	k8s.ApplyPodConfiguration(&stateFulSet.Spec.Template, cr.Spec.PodConfig)
 	return &stateFulSet, nil
 }
 
 func createEsRallyContainer(cr *v1alpha1.EsRally, name string, command []string, args string, coordinator string) corev1.Container {
-	return corev1.Container{
+	container := corev1.Container{
 		Name:            name,
 		Image:           cr.Spec.Image.Name,
 		ImagePullPolicy: corev1.PullPolicy(cr.Spec.Image.PullPolicy),
@@ -169,4 +172,14 @@ func createEsRallyContainer(cr *v1alpha1.EsRally, name string, command []string,
 		Command: command,
 		Args:    []string{args},
 	}
+
+	if security := cr.Spec.Security; security != nil && security.BasicAuth != nil &&
+		security.BasicAuth.PasswordSecretRef != nil {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:      basicAuthPasswordEnv,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: security.BasicAuth.PasswordSecretRef},
+		})
+	}
+
+	return container
 }
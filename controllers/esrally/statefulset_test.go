@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package esrally
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("EsRally containers", func() {
+	var cr perfv1alpha1.EsRally
+
+	BeforeEach(func() {
+		cr = perfv1alpha1.EsRally{
+			ObjectMeta: metav1.ObjectMeta{Name: "esrally-sample", Namespace: "kubestone"},
+			Spec: perfv1alpha1.EsRallySpec{
+				Image: perfv1alpha1.ImageSpec{Name: "xridge/esrally:1.4.0"},
+				Track: "geonames",
+				Hosts: "elasticsearch:9200",
+				Persistence: perfv1alpha1.EsRallyVolConfig{
+					Size:         "1Gi",
+					StorageClass: "standard",
+				},
+				Security: &perfv1alpha1.EsRallySecurity{
+					BasicAuth: &perfv1alpha1.BasicAuth{
+						Username: "elastic",
+						PasswordSecretRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "es-credentials"},
+							Key:                  "password",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("with the password referred from a Secret", func() {
+		It("should let Kubernetes expand the password in the daemon arguments", func() {
+			statefulSet, err := NewStatefulSet(&cr, "10.0.0.1")
+			Expect(err).To(BeNil())
+			container := statefulSet.Spec.Template.Spec.Containers[0]
+			Expect(container.Command).To(Equal([]string{"/kubestone.sh"}))
+			Expect(container.Args).To(HaveLen(1))
+			Expect(container.Args[0]).To(ContainSubstring("basic_auth_password:'$(ES_BASIC_AUTH_PASSWORD)'"))
+			Expect(container.Args[0]).NotTo(ContainSubstring("${"))
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name:      "ES_BASIC_AUTH_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: cr.Spec.Security.BasicAuth.PasswordSecretRef},
+			}))
+		})
+
+		It("should let Kubernetes expand the password in the coordinator arguments", func() {
+			job := NewJob(&cr)
+			container := job.Spec.Template.Spec.Containers[0]
+			Expect(container.Args).To(HaveLen(1))
+			Expect(container.Args[0]).To(ContainSubstring("basic_auth_password:'$(ES_BASIC_AUTH_PASSWORD)'"))
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{
+				Name:      "ES_BASIC_AUTH_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: cr.Spec.Security.BasicAuth.PasswordSecretRef},
+			}))
+		})
+	})
+
+	Context("with a plain password", func() {
+		It("should pass the password as is", func() {
+			cr.Spec.Security.BasicAuth.PasswordSecretRef = nil
+			cr.Spec.Security.BasicAuth.Password = "changeme"
+			statefulSet, err := NewStatefulSet(&cr, "10.0.0.1")
+			Expect(err).To(BeNil())
+			container := statefulSet.Spec.Template.Spec.Containers[0]
+			Expect(container.Args[0]).To(ContainSubstring("basic_auth_password:'changeme'"))
+			for _, env := range container.Env {
+				Expect(env.Name).NotTo(Equal("ES_BASIC_AUTH_PASSWORD"))
+			}
+		})
+	})
+})
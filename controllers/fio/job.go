@@ -17,6 +17,8 @@ limitations under the License.
 package fio
 
 import (
+	"errors"
+
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -72,12 +74,17 @@ func NewJob(cr *perfv1alpha1.Fio) *batchv1.Job {
 	job.Spec.Template.Spec.Volumes = volumes
 	job.Spec.Template.Spec.Containers[0].Args = fioCmdLineArgs
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
 
 // IsCrValid validates the given CR and raises error if semantic errors detected
 // For fio, the VolumeSpec validity is checked
 func IsCrValid(cr *perfv1alpha1.Fio) (valid bool, err error) {
+	if cr.Spec.FanOut != nil && cr.Spec.Volume.PersistentVolumeClaimSpec != nil {
+		return false, errors.New("fanOut cannot be used with a generated PersistentVolumeClaim, " +
+			"as the claim cannot be mounted on every node")
+	}
 	// TODO: Add check here for CustomJobs
 	return cr.Spec.Volume.Validate()
 }
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -33,9 +33,10 @@ var _ = Describe("fio job", func() {
 			cr = perfv1alpha1.Fio{
 				Spec: perfv1alpha1.FioSpec{
 					Image: perfv1alpha1.ImageSpec{
-						Name:       "xridge/fio:test",
-						PullPolicy: "Always",
-						PullSecret: "a-pull-secret",
+						Name:        "xridge/fio:test",
+						PullPolicy:  "Always",
+						PullSecret:  "a-pull-secret",
+						PullSecrets: []string{"another-pull-secret", "a-pull-secret"},
 					},
 					CmdLineArgs: "--name=randwrite --iodepth=1 --rw=randwrite --bs=4m --direct=1 --size=256M --numjobs=1",
 				},
@@ -53,6 +54,15 @@ var _ = Describe("fio job", func() {
 					ContainElement("--size=256M"))
 			})
 		})
+
+		Context("with pull secrets specified", func() {
+			It("should have each of them once", func() {
+				Expect(job.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{
+					{Name: "a-pull-secret"},
+					{Name: "another-pull-secret"},
+				}))
+			})
+		})
 	})
 
 	Describe("cr with builtin job files and volume", func() {
@@ -90,6 +100,9 @@ var _ = Describe("fio job", func() {
 				Expect(job.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(
 					Equal(corev1.PullPolicy(cr.Spec.Image.PullPolicy)))
 			})
+			It("should not have empty pull secrets", func() {
+				Expect(job.Spec.Template.Spec.ImagePullSecrets).To(BeEmpty())
+			})
 		})
 
 		Context("with builtin job files specified", func() {
@@ -137,4 +150,94 @@ var _ = Describe("fio job", func() {
 			})
 		})
 	})
+
+	Describe("cr with pod configuration", func() {
+		var cr perfv1alpha1.Fio
+		var job *batchv1.Job
+
+		BeforeEach(func() {
+			cr = perfv1alpha1.Fio{
+				Spec: perfv1alpha1.FioSpec{
+					Image: perfv1alpha1.ImageSpec{
+						Name: "xridge/fio:test",
+					},
+					Volume: perfv1alpha1.VolumeSpec{
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{},
+						},
+					},
+					PodConfig: perfv1alpha1.PodConfigurationSpec{
+						ServiceAccountName: "fio",
+						PriorityClassName:  "benchmarks",
+						SecurityProfile:    perfv1alpha1.SecurityProfileRestricted,
+						Env: []corev1.EnvVar{
+							{Name: "FIO_EXTRA", Value: "1"},
+						},
+						Volumes: []corev1.Volume{
+							{
+								Name: "extra",
+								VolumeSource: corev1.VolumeSource{
+									EmptyDir: &corev1.EmptyDirVolumeSource{},
+								},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "extra", MountPath: "/extra"},
+						},
+					},
+				},
+			}
+			job = NewJob(&cr)
+		})
+
+		Context("when instantiated", func() {
+			It("should use the service account and the priority class", func() {
+				Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal("fio"))
+				Expect(job.Spec.Template.Spec.PriorityClassName).To(Equal("benchmarks"))
+			})
+			It("should add the environment variables", func() {
+				Expect(job.Spec.Template.Spec.Containers[0].Env).To(
+					ContainElement(corev1.EnvVar{Name: "FIO_EXTRA", Value: "1"}))
+			})
+			It("should keep the benchmark volume next to the extra one", func() {
+				Expect(job.Spec.Template.Spec.Volumes).To(HaveLen(2))
+				Expect(job.Spec.Template.Spec.Volumes[1].Name).To(Equal("extra"))
+				Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(
+					ContainElement(corev1.VolumeMount{Name: "extra", MountPath: "/extra"}))
+			})
+			It("should apply the restricted security profile", func() {
+				Expect(*job.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+				securityContext := job.Spec.Template.Spec.Containers[0].SecurityContext
+				Expect(*securityContext.AllowPrivilegeEscalation).To(BeFalse())
+				Expect(securityContext.Capabilities.Drop).To(
+					ConsistOf(corev1.Capability("ALL")))
+				Expect(job.Spec.Template.Annotations).To(
+					HaveKeyWithValue("seccomp.security.alpha.kubernetes.io/pod", "runtime/default"))
+			})
+		})
+	})
+
+	Describe("fanned out cr", func() {
+		It("should reject a generated PersistentVolumeClaim", func() {
+			cr := perfv1alpha1.Fio{Spec: perfv1alpha1.FioSpec{
+				FanOut: &perfv1alpha1.FanOutSpec{},
+				Volume: perfv1alpha1.VolumeSpec{
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: perfv1alpha1.GeneratedPVC,
+						},
+					},
+					PersistentVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{},
+				},
+			}}
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+
+			cr.Spec.FanOut = nil
+			valid, err = IsCrValid(&cr)
+			Expect(valid).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
 })
@@ -17,22 +17,29 @@ limitations under the License.
 package ioping
 
 import (
-	"context"
-
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	corev1 "k8s.io/api/core/v1"
-
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
 )
 
 // Reconciler provides fields from manager to reconciler
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=iopings,verbs=get;list;watch;create;update;patch;delete
@@ -41,79 +48,66 @@ type Reconciler struct {
 
 // Reconcile creates ioping job based on the custom resource
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-
-	var cr perfv1alpha1.Ioping
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("ioping", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	// Run to one completion
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
+// NewObject returns an empty Ioping CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.Ioping{}
+}
 
-	// Validate on first entry
-	if !cr.Status.Completed && !cr.Status.Running {
-		if valid, err := IsCrValid(&cr); !valid {
-			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed,
-				"CR validation failed: %v", err)
+// Default fills the unset images of the Ioping CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.Ioping)
+	r.Images.Default("ioping", &cr.Spec.Image)
+}
 
-			// Do not requeue invalid CRs
-			return ctrl.Result{}, nil
-		}
-	}
+// Validate checks the Ioping CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.Ioping))
+	return err
+}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Steps returns the optional PersistentVolumeClaim and the Job of the ioping benchmark
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.Ioping)
 
+	var objects []metav1.Object
 	if cr.Spec.Volume.PersistentVolumeClaimSpec != nil {
 		pvc := k8s.NewPersistentVolumeClaim(*cr.Spec.Volume.PersistentVolumeClaimSpec,
 			cr.Name, cr.Namespace)
-		if err := r.K8S.CreateWithReference(ctx, pvc, &cr); err != nil {
-			return ctrl.Result{}, err
-		}
+		objects = append(objects, pvc)
 		// Change ClaimName (from GENERATED) to the PVC was created
 		cr.Spec.Volume.VolumeSource.PersistentVolumeClaim.ClaimName = cr.Name
 	}
+	objects = append(objects, NewJob(cr))
 
-	job := NewJob(&cr)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Check if finished
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
-	}
-
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	return ctrl.Result{}, nil
+	return []benchmark.Step{{Objects: objects}}, nil
+}
 
+// CollectResults parses the output of the ioping job
+func (r *Reconciler) CollectResults(object benchmark.Object, job *batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error) {
+	return results.Collect(&r.K8S, types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, results.ParseIoping)
 }
 
 // SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.Ioping{}).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-ioping,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=iopings,verbs=create;update,versions=v1alpha1,name=vioping.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of Ioping CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-ioping", r)
+}
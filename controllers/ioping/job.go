@@ -17,6 +17,8 @@ limitations under the License.
 package ioping
 
 import (
+	"errors"
+
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,11 +49,16 @@ func NewJob(cr *perfv1alpha1.Ioping) *batchv1.Job {
 	job.Spec.Template.Spec.Volumes = volumes
 	job.Spec.Template.Spec.Containers[0].Args = args
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
 
 // IsCrValid validates the given CR and raises error if semantic errors detected
 // For IOPing, the VolumeSpec validity is checked
 func IsCrValid(cr *perfv1alpha1.Ioping) (valid bool, err error) {
+	if cr.Spec.FanOut != nil && cr.Spec.Volume.PersistentVolumeClaimSpec != nil {
+		return false, errors.New("fanOut cannot be used with a generated PersistentVolumeClaim, " +
+			"as the claim cannot be mounted on every node")
+	}
 	return cr.Spec.Volume.Validate()
 }
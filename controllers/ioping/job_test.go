@@ -89,4 +89,45 @@ var _ = Describe("ioping job", func() {
 			})
 		})
 	})
+
+	Describe("validation", func() {
+		var cr perfv1alpha1.Ioping
+
+		BeforeEach(func() {
+			cr = perfv1alpha1.Ioping{
+				Spec: perfv1alpha1.IopingSpec{
+					Volume: perfv1alpha1.VolumeSpec{
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: perfv1alpha1.GeneratedPVC,
+							},
+						},
+						PersistentVolumeClaimSpec: &corev1.PersistentVolumeClaimSpec{},
+					},
+				},
+			}
+		})
+
+		It("should accept a generated PVC", func() {
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("should require the PVC spec for a generated PVC", func() {
+			cr.Spec.Volume.PersistentVolumeClaimSpec = nil
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should require a PVC volume source for the PVC spec", func() {
+			cr.Spec.Volume.VolumeSource = corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -17,7 +17,9 @@ limitations under the License.
 package iperf3
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -67,5 +69,30 @@ func NewClientJob(cr *perfv1alpha1.Iperf3) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Args = iperfCmdLineArgs
 	job.Spec.Template.Spec.HostNetwork = cr.Spec.ClientConfiguration.HostNetwork
 
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.ClientConfiguration.PodConfigurationSpec)
 	return job
 }
+
+// managedFlags are set by Kubestone and therefore cannot be
+// overridden via the command line arguments
+var managedFlags = []string{"-c", "--client", "-s", "--server", "-p", "--port", "-u", "--udp"}
+
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For iperf3, the command line arguments must not contain the flags managed by Kubestone
+func IsCrValid(cr *perfv1alpha1.Iperf3) (valid bool, err error) {
+	configurations := map[string]string{
+		"serverConfiguration": cr.Spec.ServerConfiguration.CmdLineArgs,
+		"clientConfiguration": cr.Spec.ClientConfiguration.CmdLineArgs,
+	}
+	for name, cmdLineArgs := range configurations {
+		for _, arg := range qsplit.ToStrings([]byte(cmdLineArgs)) {
+			for _, flag := range managedFlags {
+				if arg == flag || strings.HasPrefix(arg, flag+"=") {
+					return false, fmt.Errorf("You can't specify the flag '%s' in %s.cmdLineArgs", flag, name)
+				}
+			}
+		}
+	}
+
+	return true, nil
+}
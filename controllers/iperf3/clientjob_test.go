@@ -119,4 +119,38 @@ var _ = Describe("Client Pod", func() {
 			})
 		})
 	})
+
+	Describe("validation", func() {
+		var cr ksapi.Iperf3
+
+		BeforeEach(func() {
+			cr = ksapi.Iperf3{
+				Spec: ksapi.Iperf3Spec{
+					ClientConfiguration: ksapi.Iperf3ConfigurationSpec{
+						CmdLineArgs: "--time 10 --parallel 2",
+					},
+				},
+			}
+		})
+
+		It("accepts arguments not managed by kubestone", func() {
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the port flag in the client arguments", func() {
+			cr.Spec.ClientConfiguration.CmdLineArgs = "--time 10 --port 5202"
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects the client flag in the server arguments", func() {
+			cr.Spec.ServerConfiguration.CmdLineArgs = "-c other-host"
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -20,10 +20,16 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 )
@@ -32,6 +38,12 @@ import (
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=iperf3s,verbs=get;list;watch;create;update;patch;delete
@@ -46,80 +58,84 @@ type Reconciler struct {
 // deployment completes. Once the iperf3 client pod is completed,
 // the server deployment and service objects are removed from k8s.
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-
-	var cr perfv1alpha1.Iperf3
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-
-	// Run to one completion
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
-
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	serverDeployment := NewServerDeployment(&cr)
-	if err := r.K8S.CreateWithReference(ctx, serverDeployment, &cr); err != nil {
-		return ctrl.Result{}, err
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("iperf3", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	serverService := NewServerService(&cr)
-	if err := r.K8S.CreateWithReference(ctx, serverService, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// NewObject returns an empty Iperf3 CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.Iperf3{}
+}
 
-	endpointReady, err := r.K8S.IsEndpointReady(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !endpointReady {
-		// Wait for deployment to be connected to the service endpoint
-		return ctrl.Result{Requeue: true}, nil
-	}
+// Default fills the unset images of the Iperf3 CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.Iperf3)
+	r.Images.Default("iperf3", &cr.Spec.Image)
+}
 
-	if err := r.K8S.CreateWithReference(ctx, NewClientJob(&cr), &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Validate checks the Iperf3 CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.Iperf3))
+	return err
+}
 
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      clientJobName(&cr),
-	})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
-	}
+// Steps returns the server deployment and service, which have to be
+// connected via the service endpoint before the client job is created
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.Iperf3)
+
+	return []benchmark.Step{
+		{
+			Objects: []metav1.Object{NewServerDeployment(cr), NewServerService(cr)},
+			Ready: func() (bool, error) {
+				return r.K8S.IsEndpointReady(types.NamespacedName{
+					Namespace: cr.Namespace,
+					Name:      serverServiceName(cr),
+				})
+			},
+			// Endpoints are not owned by the CR, so they are polled
+			Poll: true,
+		},
+		{
+			Objects: []metav1.Object{NewClientJob(cr)},
+		},
+	}, nil
+}
 
-	if err := r.K8S.DeleteObject(ctx, serverService, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// CollectResults parses the output of the iperf3 client job
+func (r *Reconciler) CollectResults(object benchmark.Object, job *batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error) {
+	return results.Collect(&r.K8S, types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, results.ParseIperf3)
+}
 
-	if err := r.K8S.DeleteObject(ctx, serverDeployment, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Cleanup removes the server deployment and service once the client job is finished
+func (r *Reconciler) Cleanup(ctx context.Context, object benchmark.Object) error {
+	cr := object.(*perfv1alpha1.Iperf3)
 
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
+	if err := r.K8S.DeleteObject(ctx, NewServerService(cr), cr); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	return r.K8S.DeleteObject(ctx, NewServerDeployment(cr), cr)
 }
 
 // SetupWithManager registers the Iperf3Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.Iperf3{}).
+		Owns(&batchv1.Job{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-iperf3,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=iperf3s,verbs=create;update,versions=v1alpha1,name=viperf3.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of Iperf3 CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-iperf3", r)
+}
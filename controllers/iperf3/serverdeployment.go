@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
 
 	"github.com/firepear/qsplit"
 )
@@ -88,11 +89,7 @@ func NewServerDeployment(cr *perfv1alpha1.Iperf3) *appsv1.Deployment {
 					Annotations: cr.Spec.ServerConfiguration.PodConfigurationSpec.Annotations,
 				},
 				Spec: corev1.PodSpec{
-					ImagePullSecrets: []corev1.LocalObjectReference{
-						{
-							Name: cr.Spec.Image.PullSecret,
-						},
-					},
+					ImagePullSecrets: k8s.ImagePullSecrets(cr.Spec.Image),
 					Containers: []corev1.Container{
 						{
 							Name:            "server",
@@ -135,5 +132,6 @@ func NewServerDeployment(cr *perfv1alpha1.Iperf3) *appsv1.Deployment {
 		},
 	}
 
+	k8s.ApplyPodConfiguration(&deployment.Spec.Template, cr.Spec.ServerConfiguration.PodConfigurationSpec)
 	return &deployment
 }
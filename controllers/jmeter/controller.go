@@ -23,11 +23,14 @@ import (
 	"strings"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
 )
 
@@ -35,6 +38,12 @@ import (
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=jmeters,verbs=get;list;watch;create;update;patch;delete
@@ -43,127 +52,113 @@ type Reconciler struct {
 
 // Reconcile creates jmeter job(s) based on the custom resource(s)
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-
-	var cr perfv1alpha1.JMeter
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("jmeter", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	// Validate on first entry
-	if !cr.Status.Completed && !cr.Status.Running {
-		if valid, err := IsCrValid(&cr); !valid {
-			_ = r.K8S.RecordEventf(&cr, corev1.EventTypeWarning, k8s.CreateFailed,
-				"CR validation failed: %v", err)
-			cr.Status.Valid = false
-			if err := r.K8S.Client.Update(ctx, &cr); err != nil {
-				return ctrl.Result{}, err
-			}
+// NewObject returns an empty JMeter CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.JMeter{}
+}
 
-			// Do not requeue invalid CRs
-			return ctrl.Result{}, nil
-		}
+// Default fills the unset images of the JMeter CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.JMeter)
+	if cr.Spec.Controller != nil {
+		r.Images.Default("jmeter", &cr.Spec.Controller.Image)
 	}
-
-	cr.Status.Valid = true
-	cr.Status.Running = true
-	if err := r.K8S.Client.Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
+	if cr.Spec.Workers != nil {
+		r.Images.Default("jmeter", &cr.Spec.Workers.Image)
 	}
+}
+
+// Validate checks the JMeter CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.JMeter))
+	return err
+}
 
+// Steps returns the optional PersistentVolumeClaim, the ConfigMaps,
+// the optional worker StatefulSet and Service and the controller Job
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.JMeter)
+
+	var objects []metav1.Object
 	if cr.Spec.Controller.Volume.PersistentVolumeClaimSpec != nil {
 		pvc := k8s.NewPersistentVolumeClaim(*cr.Spec.Controller.Volume.PersistentVolumeClaimSpec,
 			cr.Name, cr.Namespace)
-		if err := r.K8S.CreateWithReference(ctx, pvc, &cr); err != nil {
-			return ctrl.Result{}, err
-		}
+		objects = append(objects, pvc)
 		// Change ClaimName (from GENERATED) to the PVC was created
 		cr.Spec.Controller.Volume.VolumeSource.PersistentVolumeClaim.ClaimName = cr.Name
 	}
 
-	// Create the planTestConfigMap
-	planTestConfigMap, err := NewPlanTestConfigMap(&cr)
-
+	planTestConfigMap, err := NewPlanTestConfigMap(cr)
 	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	if err := r.K8S.CreateWithReference(ctx, planTestConfigMap, &cr); err != nil {
-		return ctrl.Result{}, err
+		return nil, err
 	}
+	objects = append(objects, planTestConfigMap)
 
-	// Create the propertiesConfigMap
 	var propertiesConfigMap *corev1.ConfigMap
 	if cr.Spec.Controller.Props != nil {
-		propertiesConfigMap, err = NewPropertiesConfigMap(&cr)
-
+		propertiesConfigMap, err = NewPropertiesConfigMap(cr)
 		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		if err := r.K8S.CreateWithReference(ctx, propertiesConfigMap, &cr); err != nil {
-			return ctrl.Result{}, err
+			return nil, err
 		}
+		objects = append(objects, propertiesConfigMap)
 	}
 
 	if cr.Spec.Workers != nil {
-		statefulset, err := NewStatefulSet(&cr)
+		statefulset, err := NewStatefulSet(cr)
 		if err != nil {
-			return ctrl.Result{}, err
-		}
-
-		if err := r.K8S.CreateWithReference(ctx, statefulset, &cr); err != nil {
-			return ctrl.Result{}, err
-		}
-
-		service := NewService(&cr, statefulset.Labels)
-		if err := r.K8S.CreateWithReference(ctx, service, &cr); err != nil {
-			return ctrl.Result{}, err
+			return nil, err
 		}
+		objects = append(objects, statefulset, NewService(cr, statefulset.Labels))
 	}
 
-	// Create the job
-	job := NewJob(&cr, planTestConfigMap, propertiesConfigMap)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+	objects = append(objects, NewJob(cr, planTestConfigMap, propertiesConfigMap))
 
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
-	}
+	return []benchmark.Step{{Objects: objects}}, nil
+}
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+// Cleanup removes the worker StatefulSet and Service once the
+// controller job is finished
+func (r *Reconciler) Cleanup(ctx context.Context, object benchmark.Object) error {
+	cr := object.(*perfv1alpha1.JMeter)
+	if cr.Spec.Workers == nil {
+		return nil
 	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
+	objectMeta := metav1.ObjectMeta{Name: cr.Name, Namespace: cr.Namespace}
+
+	if err := r.K8S.DeleteObject(ctx, &corev1.Service{ObjectMeta: objectMeta}, cr); err != nil {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	return r.K8S.DeleteObject(ctx, &appsv1.StatefulSet{ObjectMeta: objectMeta}, cr)
 }
 
 // SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.JMeter{}).
+		Owns(&batchv1.Job{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
 		Complete(r)
 }
 
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-jmeter,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=jmeters,verbs=create;update,versions=v1alpha1,name=vjmeter.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of JMeter CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-jmeter", r)
+}
+
 // IsCrValid validates the given CR and raises error if semantic errors detected
 // For jmeter it checks that the plan test is valid
 func IsCrValid(cr *perfv1alpha1.JMeter) (valid bool, err error) {
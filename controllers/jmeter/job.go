@@ -110,6 +110,7 @@ func NewJob(cr *perfv1alpha1.JMeter, planTestConfigMap, propertiesConfigMap *cor
 	job.Spec.Template.Spec.Containers[0].Command = qsplit.ToStrings([]byte(cr.Spec.Controller.Command))
 	job.Spec.Template.Spec.Containers[0].VolumeMounts = volumeMounts
 	job.Spec.Template.Spec.InitContainers = initContainers
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.Controller.Configuration)
 	return job
 }
 
@@ -21,6 +21,7 @@ import (
 
 	"github.com/firepear/qsplit"
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -107,17 +108,16 @@ func NewStatefulSet(cr *perfv1alpha1.JMeter) (*v1.StatefulSet, error) {
 							},
 						},
 					},
-					ImagePullSecrets: []corev1.LocalObjectReference{
-						{Name: cr.Spec.Workers.Image.PullSecret},
-					},
-					Affinity:     cr.Spec.Workers.Configuration.PodScheduling.Affinity,
-					Tolerations:  cr.Spec.Workers.Configuration.PodScheduling.Tolerations,
-					NodeSelector: cr.Spec.Workers.Configuration.PodScheduling.NodeSelector,
-					NodeName:     cr.Spec.Workers.Configuration.PodScheduling.NodeName,
+					ImagePullSecrets: k8s.ImagePullSecrets(cr.Spec.Workers.Image),
+					Affinity:         cr.Spec.Workers.Configuration.PodScheduling.Affinity,
+					Tolerations:      cr.Spec.Workers.Configuration.PodScheduling.Tolerations,
+					NodeSelector:     cr.Spec.Workers.Configuration.PodScheduling.NodeSelector,
+					NodeName:         cr.Spec.Workers.Configuration.PodScheduling.NodeName,
 				},
 			},
 		},
 	}
 
+	k8s.ApplyPodConfiguration(&statefulset.Spec.Template, cr.Spec.Workers.Configuration)
 	return statefulset, nil
 }
@@ -17,113 +17,148 @@ limitations under the License.
 package kafkabench
 
 import (
-	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/go-logr/logr"
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	// TestAnnotation holds the name of the test of a producer or consumer job
+	TestAnnotation = "kubestone.xridge.io/kafka-test"
+
+	// RoleAnnotation tells whether a job is a producer or a consumer
+	RoleAnnotation = "kubestone.xridge.io/kafka-role"
+
+	// ProducerRole is the role of the producer jobs
+	ProducerRole = "producer"
+
+	// ConsumerRole is the role of the consumer jobs
+	ConsumerRole = "consumer"
+)
+
 // KafkaBenchReconciler reconciles a KafkaBench object
 type KafkaBenchReconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=kafkabenches,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=kafkabenches/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;create;delete
 
+// Reconcile creates the producer and consumer jobs of each kafka test
 func (r *KafkaBenchReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-	_ = r.Log.WithValues("kafkabench", req.NamespacedName)
-
-	var cr perfv1alpha1.KafkaBench
-
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-
-	// If its already completed then return
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("kafkabench", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	// Set status to running
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// NewObject returns an empty KafkaBench CR
+func (r *KafkaBenchReconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.KafkaBench{}
+}
 
-	// Create new jobs for each test
-	var jobs []*batchv1.Job
-	for _, testSpec := range cr.Spec.Tests {
-		result, err, kjobs := r.ProcessKafkaTest(cr, testSpec, ctx)
-		if err != nil {
-			return result, err
-		}
+// Default fills the unset images of the KafkaBench CR from the image catalog
+func (r *KafkaBenchReconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.KafkaBench)
+	r.Images.Default("kafkabench", &cr.Spec.Image)
+}
 
-		jobs = append(jobs, kjobs...)
-	}
+// Validate checks the KafkaBench CR for semantic errors
+func (r *KafkaBenchReconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.KafkaBench))
+	return err
+}
 
-	// Check all the job statuses
-	for _, job := range jobs {
-		jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-			Namespace: cr.Namespace,
-			Name:      job.Name,
-		})
+// Steps returns the consumer and producer jobs of each test
+func (r *KafkaBenchReconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.KafkaBench)
 
-		if err != nil {
-			return ctrl.Result{}, err
-		}
+	var objects []metav1.Object
+	for i := range cr.Spec.Tests {
+		testSpec := &cr.Spec.Tests[i]
+		objects = append(objects, NewConsumerJob(cr, testSpec), NewProducerJob(cr, testSpec))
+	}
 
-		if !jobFinished {
-			// Wait for the job to be completed
-			return ctrl.Result{Requeue: true}, nil
-		}
+	return []benchmark.Step{{Objects: objects}}, nil
+}
 
-	}
+// CollectResults parses the output of a producer or consumer job. The
+// metrics are labeled with the name of the test and the role of the job.
+func (r *KafkaBenchReconciler) CollectResults(object benchmark.Object, job *batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error) {
+	cr := object.(*perfv1alpha1.KafkaBench)
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	testSpec, role, err := testOfJob(cr, job)
+	if err != nil {
+		return nil, err
 	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
+	parser := results.Parser(results.ParseKafkaProducer)
+	if role == ConsumerRole {
+		parser = results.ParseKafkaConsumer
 	}
 
-	return ctrl.Result{}, nil
+	metrics, err := results.Collect(&r.K8S, types.NamespacedName{
+		Namespace: job.Namespace,
+		Name:      job.Name,
+	}, parser)
+	if err != nil {
+		return nil, err
+	}
+	return results.WithLabels(metrics, map[string]string{"test": testSpec.Name, "role": role}), nil
 }
 
-func (r *KafkaBenchReconciler) ProcessKafkaTest(cr perfv1alpha1.KafkaBench, testSpec perfv1alpha1.KafkaTestSpec, ctx context.Context) (ctrl.Result, error, []*batchv1.Job) {
-
-	// Create producer job
-	producerJob := NewProducerJob(&cr, &testSpec)
-	if err := r.K8S.CreateWithReference(ctx, producerJob, &cr); err != nil {
-		return ctrl.Result{}, err, nil
+// testOfJob returns the test and the role of a producer or consumer job.
+// The jobs are identified by their annotations, as the engine renames
+// them for the reruns and the fan-out.
+func testOfJob(cr *perfv1alpha1.KafkaBench, job *batchv1.Job) (*perfv1alpha1.KafkaTestSpec, string, error) {
+	role := job.Annotations[RoleAnnotation]
+	if role != ProducerRole && role != ConsumerRole {
+		return nil, "", fmt.Errorf("job %v is neither a producer nor a consumer", job.Name)
 	}
-
-	// Create consumer job
-	consumerJob := NewConsumerJob(&cr, &testSpec)
-	if err := r.K8S.CreateWithReference(ctx, consumerJob, &cr); err != nil {
-		return ctrl.Result{}, err, nil
+	for i := range cr.Spec.Tests {
+		if cr.Spec.Tests[i].Name == job.Annotations[TestAnnotation] {
+			return &cr.Spec.Tests[i], role, nil
+		}
 	}
-
-	return ctrl.Result{}, nil, []*batchv1.Job{consumerJob, producerJob}
+	return nil, "", fmt.Errorf("job %v does not belong to any test", job.Name)
 }
 
 func (r *KafkaBenchReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.KafkaBench{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
 
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-kafkabench,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=kafkabenches,verbs=create;update,versions=v1alpha1,name=vkafkabench.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of KafkaBench CRs
+func (r *KafkaBenchReconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-kafkabench", r)
+}
+
 func AddPodAffinity(job *batchv1.Job, jobName string) {
 	affinity := corev1.WeightedPodAffinityTerm{
 		Weight: 1,
@@ -158,3 +193,24 @@ func AddPodAffinity(job *batchv1.Job, jobName string) {
 		)
 	}
 }
+
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For kafkabench it checks that the test names can be used in the job names
+func IsCrValid(cr *perfv1alpha1.KafkaBench) (valid bool, err error) {
+	if len(cr.Spec.Tests) == 0 {
+		return false, errors.New("At least one test must be specified in tests")
+	}
+
+	names := map[string]bool{}
+	for _, test := range cr.Spec.Tests {
+		if msgs := validation.IsDNS1123Label(test.Name); len(msgs) > 0 {
+			return false, fmt.Errorf("The test name '%s' is invalid: %s", test.Name, strings.Join(msgs, ", "))
+		}
+		if names[test.Name] {
+			return false, fmt.Errorf("The test name '%s' is not unique", test.Name)
+		}
+		names[test.Name] = true
+	}
+
+	return true, nil
+}
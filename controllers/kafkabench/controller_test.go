@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkabench
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ksapi "github.com/xridge/kubestone/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+var _ = Describe("KafkaBench validation", func() {
+	var cr ksapi.KafkaBench
+
+	BeforeEach(func() {
+		cr = ksapi.KafkaBench{
+			Spec: ksapi.KafkaBenchSpec{
+				Tests: []ksapi.KafkaTestSpec{
+					{Name: "noreplication", Replication: 1},
+					{Name: "replication", Replication: 3},
+				},
+			},
+		}
+	})
+
+	It("should accept DNS-safe unique test names", func() {
+		valid, err := IsCrValid(&cr)
+		Expect(valid).To(BeTrue())
+		Expect(err).To(BeNil())
+	})
+
+	It("should require at least one test", func() {
+		cr.Spec.Tests = nil
+		valid, err := IsCrValid(&cr)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject test names which are not DNS-safe", func() {
+		cr.Spec.Tests[0].Name = "No_Replication"
+		valid, err := IsCrValid(&cr)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject duplicated test names", func() {
+		cr.Spec.Tests[1].Name = cr.Spec.Tests[0].Name
+		valid, err := IsCrValid(&cr)
+		Expect(valid).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("KafkaBench results", func() {
+	var cr ksapi.KafkaBench
+
+	BeforeEach(func() {
+		cr = ksapi.KafkaBench{
+			Spec: ksapi.KafkaBenchSpec{
+				Tests: []ksapi.KafkaTestSpec{
+					{Name: "noreplication", Replication: 1},
+					{Name: "replication", Replication: 3},
+				},
+			},
+		}
+		cr.Name = "kafkabench-sample"
+	})
+
+	It("should match the jobs of the first run", func() {
+		test, role, err := testOfJob(&cr, NewProducerJob(&cr, &cr.Spec.Tests[1]))
+		Expect(err).To(BeNil())
+		Expect(test.Name).To(Equal("replication"))
+		Expect(role).To(Equal(ProducerRole))
+	})
+
+	It("should match the renamed jobs of the reruns", func() {
+		for run := int32(2); run <= 3; run++ {
+			job := NewConsumerJob(&cr, &cr.Spec.Tests[0])
+			job.Name = ksapi.RunName(job.Name, run)
+			test, role, err := testOfJob(&cr, job)
+			Expect(err).To(BeNil())
+			Expect(test.Name).To(Equal("noreplication"))
+			Expect(role).To(Equal(ConsumerRole))
+		}
+	})
+
+	It("should reject jobs which do not belong to any test", func() {
+		job := NewProducerJob(&cr, &cr.Spec.Tests[0])
+		cr.Spec.Tests = cr.Spec.Tests[1:]
+		_, _, err := testOfJob(&cr, job)
+		Expect(err).To(HaveOccurred())
+
+		_, _, err = testOfJob(&cr, &batchv1.Job{})
+		Expect(err).To(HaveOccurred())
+	})
+})
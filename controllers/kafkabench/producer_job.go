@@ -32,6 +32,10 @@ func NewProducerJob(cr *perfv1alpha1.KafkaBench, ts *perfv1alpha1.KafkaTestSpec)
 	objectMeta := metav1.ObjectMeta{
 		Name:      jobName,
 		Namespace: cr.Namespace,
+		Annotations: map[string]string{
+			TestAnnotation: ts.Name,
+			RoleAnnotation: ProducerRole,
+		},
 	}
 
 	job := k8s.NewPerfJob(objectMeta, "kafkabench", cr.Spec.Image, cr.Spec.PodConfig)
@@ -53,6 +57,7 @@ func NewProducerJob(cr *perfv1alpha1.KafkaBench, ts *perfv1alpha1.KafkaTestSpec)
 	// Add pod affinity
 	AddPodAffinity(job, jobName)
 
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
 
@@ -17,75 +17,77 @@ limitations under the License.
 package ocplogtest
 
 import (
-	"context"
-	"github.com/xridge/kubestone/pkg/k8s"
-	"k8s.io/apimachinery/pkg/types"
-
 	"github.com/go-logr/logr"
-	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
+	"github.com/xridge/kubestone/pkg/k8s"
 )
 
 // Reconciler reconciles a OcpLogtest object
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=ocplogtests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=ocplogtests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=ocplogtests/finalizers,verbs=update
 
+// Reconcile creates ocplogtest job(s) based on the custom resource(s)
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-
-	var cr perfv1alpha1.OcpLogtest
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
-
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("ocplogtest", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	job := NewJob(&cr)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// NewObject returns an empty OcpLogtest CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.OcpLogtest{}
+}
 
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	})
+// Default fills the unset images of the OcpLogtest CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.OcpLogtest)
+	r.Images.Default("ocplogtest", &cr.Spec.Image)
+}
 
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
-	}
+// Validate checks the OcpLogtest CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	return nil
+}
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Steps returns the Job of the ocplogtest benchmark
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.OcpLogtest)
 
-	return ctrl.Result{}, nil
+	return []benchmark.Step{{Objects: []metav1.Object{NewJob(cr)}}}, nil
 }
 
+// SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.OcpLogtest{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-ocplogtest,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=ocplogtests,verbs=create;update,versions=v1alpha1,name=vocplogtest.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of OcpLogtest CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-ocplogtest", r)
+}
@@ -40,5 +40,6 @@ func NewJob(cr *perfv1alpha1.OcpLogtest) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Command = []string{"python"}
 	job.Spec.Template.Spec.Containers[0].Args = args
 
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
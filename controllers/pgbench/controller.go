@@ -17,20 +17,28 @@ limitations under the License.
 package pgbench
 
 import (
-	"context"
-
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
 )
 
 // Reconciler reconciles a Pgbench object
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=pgbenches,verbs=get;list;watch;create;update;patch;delete
@@ -39,56 +47,61 @@ type Reconciler struct {
 
 // Reconcile creates pgbench job(s) based on the custom resource(s)
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	// TODO: most of this function could be refactored to a base
-	ctx := context.Background()
-
-	var cr perfv1alpha1.Pgbench
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("pgbench", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
+// NewObject returns an empty Pgbench CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.Pgbench{}
+}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Default fills the unset images of the Pgbench CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.Pgbench)
+	r.Images.Default("pgbench", &cr.Spec.Image)
+}
 
-	job := NewJob(&cr)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Validate checks the Pgbench CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.Pgbench))
+	return err
+}
 
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
-	}
+// ValidateReferences checks that the referred password Secret exists
+func (r *Reconciler) ValidateReferences(object benchmark.Object) error {
+	cr := object.(*perfv1alpha1.Pgbench)
+	return r.K8S.CheckSecretKeyRef(cr.Namespace, cr.Spec.Postgres.PasswordSecretRef)
+}
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Steps returns the Job of the pgbench benchmark
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.Pgbench)
 
-	return ctrl.Result{}, nil
+	return []benchmark.Step{{Objects: []metav1.Object{NewJob(cr)}}}, nil
+}
+
+// CollectResults parses the output of the pgbench job
+func (r *Reconciler) CollectResults(object benchmark.Object, job *batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error) {
+	return results.Collect(&r.K8S, types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, results.ParsePgbench)
 }
 
 // SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.Pgbench{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-pgbench,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=pgbenches,verbs=create;update,versions=v1alpha1,name=vpgbench.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of Pgbench CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-pgbench", r)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package pgbench
 
 import (
+	"errors"
 	"fmt"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -28,6 +29,16 @@ import (
 	"github.com/xridge/kubestone/pkg/k8s"
 )
 
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For pgbench it checks that the password is specified only once
+func IsCrValid(cr *perfv1alpha1.Pgbench) (valid bool, err error) {
+	if cr.Spec.Postgres.Password != "" && cr.Spec.Postgres.PasswordSecretRef != nil {
+		return false, errors.New("Only one of postgres.password and postgres.passwordSecretRef can be specified")
+	}
+
+	return true, nil
+}
+
 // NewJob creates a new pgbench job
 func NewJob(cr *perfv1alpha1.Pgbench) *batchv1.Job {
 	objectMeta := metav1.ObjectMeta{
@@ -39,9 +50,18 @@ func NewJob(cr *perfv1alpha1.Pgbench) *batchv1.Job {
 		{Name: "PGHOST", Value: cr.Spec.Postgres.Host},
 		{Name: "PGPORT", Value: fmt.Sprintf("%d", cr.Spec.Postgres.Port)},
 		{Name: "PGUSER", Value: cr.Spec.Postgres.User},
-		{Name: "PGPASSWORD", Value: cr.Spec.Postgres.Password},
 		{Name: "PGDATABASE", Value: cr.Spec.Postgres.Database},
 	}
+	if cr.Spec.Postgres.PasswordSecretRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "PGPASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: cr.Spec.Postgres.PasswordSecretRef,
+			},
+		})
+	} else {
+		env = append(env, corev1.EnvVar{Name: "PGPASSWORD", Value: cr.Spec.Postgres.Password})
+	}
 
 	initContainer := corev1.Container{
 		Name:            "pgbench-init",
@@ -58,5 +78,6 @@ func NewJob(cr *perfv1alpha1.Pgbench) *batchv1.Job {
 		job.Spec.Template.Spec.InitContainers, initContainer)
 	job.Spec.Template.Spec.Containers[0].Args = qsplit.ToStrings([]byte(cr.Spec.Args))
 	job.Spec.Template.Spec.Containers[0].Env = env
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
@@ -121,4 +121,48 @@ var _ = Describe("pgbench job", func() {
 		})
 
 	})
+
+	Describe("with password from a Secret", func() {
+		cr := perfv1alpha1.Pgbench{
+			Spec: perfv1alpha1.PgbenchSpec{
+				Postgres: perfv1alpha1.PostgresSpec{
+					Host: "postgres",
+					User: "admin",
+					PasswordSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "postgres"},
+						Key:                  "password",
+					},
+				},
+			},
+		}
+		job := NewJob(&cr)
+
+		It("should refer the Secret in the password env var", func() {
+			for _, container := range []corev1.Container{
+				job.Spec.Template.Spec.InitContainers[0],
+				job.Spec.Template.Spec.Containers[0],
+			} {
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{
+					Name: "PGPASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: cr.Spec.Postgres.PasswordSecretRef,
+					},
+				}))
+			}
+		})
+
+		It("should be valid", func() {
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("should be invalid along with a plain password", func() {
+			invalidCr := cr
+			invalidCr.Spec.Postgres.Password = "admin"
+			valid, err := IsCrValid(&invalidCr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
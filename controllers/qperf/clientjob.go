@@ -17,6 +17,8 @@ limitations under the License.
 package qperf
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -61,5 +63,24 @@ func NewClientJob(cr *perfv1alpha1.Qperf) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Args = qperfCmdLineArgs
 	job.Spec.Template.Spec.HostNetwork = cr.Spec.ClientConfiguration.HostNetwork
 
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.ClientConfiguration.PodConfigurationSpec)
 	return job
 }
+
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For qperf, at least one test is required and the listen port is managed by Kubestone
+func IsCrValid(cr *perfv1alpha1.Qperf) (valid bool, err error) {
+	if len(cr.Spec.Tests) == 0 {
+		return false, errors.New("At least one test must be specified in tests")
+	}
+
+	for _, arg := range qsplit.ToStrings([]byte(cr.Spec.Options)) {
+		for _, flag := range []string{"-lp", "--listen_port"} {
+			if arg == flag {
+				return false, fmt.Errorf("You can't specify the flag '%s' in options", flag)
+			}
+		}
+	}
+
+	return true, nil
+}
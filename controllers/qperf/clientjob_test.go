@@ -104,4 +104,37 @@ var _ = Describe("Client Pod", func() {
 			})
 		})
 	})
+
+	Describe("validation", func() {
+		var cr ksapi.Qperf
+
+		BeforeEach(func() {
+			cr = ksapi.Qperf{
+				Spec: ksapi.QperfSpec{
+					Options: "--time 10",
+					Tests:   []string{"tcp_bw"},
+				},
+			}
+		})
+
+		It("accepts a CR with tests", func() {
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a CR without tests", func() {
+			cr.Spec.Tests = nil
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("rejects the listen port in the options", func() {
+			cr.Spec.Options = "--listen_port 1234"
+			valid, err := IsCrValid(&cr)
+			Expect(valid).To(BeFalse())
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
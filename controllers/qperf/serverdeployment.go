@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
 )
 
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;create;delete;watch
@@ -64,11 +65,7 @@ func NewServerDeployment(cr *perfv1alpha1.Qperf) *appsv1.Deployment {
 					Labels: labels,
 				},
 				Spec: corev1.PodSpec{
-					ImagePullSecrets: []corev1.LocalObjectReference{
-						{
-							Name: cr.Spec.Image.PullSecret,
-						},
-					},
+					ImagePullSecrets: k8s.ImagePullSecrets(cr.Spec.Image),
 					Containers: []corev1.Container{
 						{
 							Name:            "server",
@@ -106,5 +103,6 @@ func NewServerDeployment(cr *perfv1alpha1.Qperf) *appsv1.Deployment {
 		},
 	}
 
+	k8s.ApplyPodConfiguration(&deployment.Spec.Template, cr.Spec.ServerConfiguration.PodConfigurationSpec)
 	return &deployment
 }
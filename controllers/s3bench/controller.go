@@ -17,74 +17,93 @@ limitations under the License.
 package s3bench
 
 import (
-	"context"
-	"github.com/xridge/kubestone/pkg/k8s"
-	"k8s.io/apimachinery/pkg/types"
-
 	"github.com/go-logr/logr"
-	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/benchmark"
+	"github.com/xridge/kubestone/pkg/k8s"
 )
 
 // Reconciler reconciles a S3Bench object
 type Reconciler struct {
 	K8S k8s.Access
 	Log logr.Logger
+
+	// Images are the default images of the benchmark
+	Images *benchmark.ImageCatalog
+
+	// Queue admits the benchmarks according to the concurrency limits
+	Queue *benchmark.Queue
 }
 
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=s3benches,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=s3benches/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=s3benches/finalizers,verbs=update
 
+// Reconcile creates s3bench job(s) based on the custom resource(s)
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
-	ctx := context.Background()
-
-	var cr perfv1alpha1.S3Bench
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	engine := benchmark.Engine{
+		K8S:    &r.K8S,
+		Log:    r.Log.WithValues("s3bench", req.NamespacedName),
+		Queue:  r.Queue,
+		Images: r.Images,
 	}
+	return engine.Reconcile(req, r)
+}
 
-	if cr.Status.Completed {
-		return ctrl.Result{}, nil
-	}
+// NewObject returns an empty S3Bench CR
+func (r *Reconciler) NewObject() benchmark.Object {
+	return &perfv1alpha1.S3Bench{}
+}
 
-	cr.Status.Running = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Default fills the unset images of the S3Bench CR from the image catalog
+func (r *Reconciler) Default(object benchmark.Object) {
+	cr := object.(*perfv1alpha1.S3Bench)
+	r.Images.Default("s3bench", &cr.Spec.Image)
+}
 
-	job := NewJob(&cr)
-	if err := r.K8S.CreateWithReference(ctx, job, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Validate checks the S3Bench CR for semantic errors
+func (r *Reconciler) Validate(object benchmark.Object) error {
+	_, err := IsCrValid(object.(*perfv1alpha1.S3Bench))
+	return err
+}
 
-	jobFinished, err := r.K8S.IsJobFinished(types.NamespacedName{
-		Namespace: cr.Namespace,
-		Name:      cr.Name,
-	})
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-	if !jobFinished {
-		// Wait for the job to be completed
-		return ctrl.Result{Requeue: true}, nil
+// ValidateReferences checks that the referred credential Secrets exist
+func (r *Reconciler) ValidateReferences(object benchmark.Object) error {
+	cr := object.(*perfv1alpha1.S3Bench)
+	for _, selector := range []*corev1.SecretKeySelector{
+		cr.Spec.S3BenchOptions.AccessKeySecretRef,
+		cr.Spec.S3BenchOptions.SecretKeySecretRef,
+	} {
+		if err := r.K8S.CheckSecretKeyRef(cr.Namespace, selector); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// The cr could have been modified since the last time we got it
-	if err := r.K8S.Client.Get(ctx, req.NamespacedName, &cr); err != nil {
-		return ctrl.Result{}, k8s.IgnoreNotFound(err)
-	}
-	cr.Status.Running = false
-	cr.Status.Completed = true
-	if err := r.K8S.Client.Status().Update(ctx, &cr); err != nil {
-		return ctrl.Result{}, err
-	}
+// Steps returns the Job of the s3bench benchmark
+func (r *Reconciler) Steps(object benchmark.Object) ([]benchmark.Step, error) {
+	cr := object.(*perfv1alpha1.S3Bench)
 
-	return ctrl.Result{}, nil
+	return []benchmark.Step{{Objects: []metav1.Object{NewJob(cr)}}}, nil
 }
 
+// SetupWithManager registers the Reconciler with the provided manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&perfv1alpha1.S3Bench{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
+
+// +kubebuilder:webhook:path=/validate-perf-kubestone-xridge-io-v1alpha1-s3bench,mutating=false,failurePolicy=fail,groups=perf.kubestone.xridge.io,resources=s3benches,verbs=create;update,versions=v1alpha1,name=vs3bench.kubestone.xridge.io
+
+// SetupWebhookWithManager registers the validating webhook of S3Bench CRs
+func (r *Reconciler) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return benchmark.SetupValidatingWebhook(mgr, "/validate-perf-kubestone-xridge-io-v1alpha1-s3bench", r)
+}
@@ -17,13 +17,85 @@ limitations under the License.
 package s3bench
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 	"github.com/xridge/kubestone/pkg/k8s"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strconv"
 )
 
+// modes are the operating modes of warp supported by the benchmark
+var modes = []string{"get", "put", "delete", "mixed"}
+
+// IsCrValid validates the given CR and raises error if semantic errors detected
+// For s3bench it checks the mode, the mixed distribution and the durations
+func IsCrValid(cr *perfv1alpha1.S3Bench) (valid bool, err error) {
+	if !contains(modes, cr.Spec.Mode) {
+		return false, fmt.Errorf("The mode '%s' is invalid, it must be one of %v", cr.Spec.Mode, modes)
+	}
+
+	if cr.Spec.Host == "" {
+		return false, errors.New("You need to specify the host")
+	}
+
+	opts := cr.Spec.S3BenchOptions
+	if opts.AccessKey != "" && opts.AccessKeySecretRef != nil {
+		return false, errors.New("Only one of accessKey and accessKeySecretRef can be specified")
+	}
+	if opts.SecretKey != "" && opts.SecretKeySecretRef != nil {
+		return false, errors.New("Only one of secretKey and secretKeySecretRef can be specified")
+	}
+
+	hostSelect := opts.HostSelect
+	if hostSelect != "" && hostSelect != "weighed" && hostSelect != "roundrobin" {
+		return false, fmt.Errorf("The hostSelect '%s' is invalid, it must be weighed or roundrobin", hostSelect)
+	}
+
+	dist := cr.Spec.MixedDistributionOptions
+	if (dist != perfv1alpha1.MixedDistributionOptions{}) {
+		if cr.Spec.Mode != "mixed" {
+			return false, errors.New("mixedDist can only be specified in mixed mode")
+		}
+		if sum := dist.GetDist + dist.StatDist + dist.PutDist + dist.DeleteDist; sum != 100 {
+			return false, fmt.Errorf("The sum of the mixedDist values must be 100, got %d", sum)
+		}
+		if dist.PutDist < dist.DeleteDist {
+			return false, errors.New("mixedDist.put must be at least the same as mixedDist.delete")
+		}
+	}
+
+	durations := map[string]string{
+		"duration":          opts.Duration,
+		"autoTerm.duration": cr.Spec.S3AutoTermOptions.Duration,
+		"analysis.duration": cr.Spec.S3AnalysisOptions.Duration,
+		"analysis.skip":     cr.Spec.S3AnalysisOptions.Skip,
+	}
+	for name, duration := range durations {
+		if duration == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(duration); err != nil {
+			return false, fmt.Errorf("The %s '%s' is invalid: %v", name, duration, err)
+		}
+	}
+
+	return true, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // NewJob creates a s3bench benchmark job
 func NewJob(cr *perfv1alpha1.S3Bench) *batchv1.Job {
 	objectMeta := metav1.ObjectMeta{
@@ -35,19 +107,39 @@ func NewJob(cr *perfv1alpha1.S3Bench) *batchv1.Job {
 	s3benchCmdLineArgs = append(s3benchCmdLineArgs, cr.Spec.Mode)
 	s3benchCmdLineArgs = append(s3benchCmdLineArgs, ProcessS3BenchArgs(&cr.Spec)...)
 
-	image := cr.Spec.Image
-	if (image == perfv1alpha1.ImageSpec{}) {
-		image = perfv1alpha1.ImageSpec{
-			Name:       "minio/warp:v0.3.5",
-			PullPolicy: "IfNotPresent",
-		}
-	}
-
-	job := k8s.NewPerfJob(objectMeta, "s3bench", image, cr.Spec.PodConfig)
+	job := k8s.NewPerfJob(objectMeta, "s3bench", cr.Spec.Image, cr.Spec.PodConfig)
 	job.Spec.Template.Spec.Containers[0].Args = s3benchCmdLineArgs
+	job.Spec.Template.Spec.Containers[0].Env = credentialsEnv(&cr.Spec.S3BenchOptions)
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
 
+// The environment variables holding the credentials referred from Secrets.
+// Kubernetes expands them in the arguments of the container.
+const (
+	accessKeyEnv = "S3_ACCESS_KEY"
+	secretKeyEnv = "S3_SECRET_KEY"
+)
+
+// credentialsEnv returns the environment variables of the credentials
+// which are referred from Secrets
+func credentialsEnv(opts *perfv1alpha1.S3BenchOptions) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if opts.AccessKeySecretRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name:      accessKeyEnv,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: opts.AccessKeySecretRef},
+		})
+	}
+	if opts.SecretKeySecretRef != nil {
+		env = append(env, corev1.EnvVar{
+			Name:      secretKeyEnv,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: opts.SecretKeySecretRef},
+		})
+	}
+	return env
+}
+
 func ProcessS3BenchArgs(spec *perfv1alpha1.S3BenchSpec) []string {
 	var cmdArgs []string
 	cmdArgs = append(cmdArgs, "--host", spec.Host)
@@ -59,7 +151,13 @@ func ProcessS3BenchArgs(spec *perfv1alpha1.S3BenchSpec) []string {
 		checkAndAppendBool(&cmdArgs, opts.Debug, "--debug")
 		checkAndAppendBool(&cmdArgs, opts.Debug, "--insecure")
 		checkAndAppendString(&cmdArgs, opts.AccessKey, "--access-key")
+		if opts.AccessKeySecretRef != nil {
+			cmdArgs = append(cmdArgs, "--access-key", "$("+accessKeyEnv+")")
+		}
 		checkAndAppendString(&cmdArgs, opts.SecretKey, "--secret-key")
+		if opts.SecretKeySecretRef != nil {
+			cmdArgs = append(cmdArgs, "--secret-key", "$("+secretKeyEnv+")")
+		}
 		checkAndAppendBool(&cmdArgs, opts.Tls, "--tls")
 		checkAndAppendString(&cmdArgs, opts.Region, "--region")
 		checkAndAppendBool(&cmdArgs, opts.Encrypt, "--encrypt")
@@ -38,5 +38,6 @@ func NewJob(cr *perfv1alpha1.Sysbench) *batchv1.Job {
 
 	job := k8s.NewPerfJob(objectMeta, "sysbench", cr.Spec.Image, cr.Spec.PodConfig)
 	job.Spec.Template.Spec.Containers[0].Args = sysbenchCmdLineArgs
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
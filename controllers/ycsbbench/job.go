@@ -70,5 +70,6 @@ func NewJob(cr *perfv1alpha1.YcsbBench) *batchv1.Job {
 	job.Spec.Template.Spec.Containers[0].Command = []string{"./bin/ycsb", "run"}
 	job.Spec.Template.Spec.Containers[0].Args = args
 
+	k8s.ApplyPodConfiguration(&job.Spec.Template, cr.Spec.PodConfig)
 	return job
 }
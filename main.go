@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 
 	"github.com/xridge/kubestone/controllers/esrally"
 	"github.com/xridge/kubestone/controllers/jmeter"
@@ -35,6 +36,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/controllers/benchmarkmatrix"
+	"github.com/xridge/kubestone/controllers/benchmarkschedule"
+	"github.com/xridge/kubestone/controllers/benchmarksuite"
 	"github.com/xridge/kubestone/controllers/drill"
 	"github.com/xridge/kubestone/controllers/fio"
 	"github.com/xridge/kubestone/controllers/ioping"
@@ -44,6 +48,7 @@ import (
 	"github.com/xridge/kubestone/controllers/qperf"
 	"github.com/xridge/kubestone/controllers/s3bench"
 	"github.com/xridge/kubestone/controllers/sysbench"
+	"github.com/xridge/kubestone/pkg/benchmark"
 	"github.com/xridge/kubestone/pkg/k8s"
 	// +kubebuilder:scaffold:imports
 )
@@ -63,12 +68,34 @@ func init() {
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=create
 
+// benchmarkReconciler is implemented by the reconcilers of the benchmark CRs
+type benchmarkReconciler interface {
+	SetupWithManager(mgr ctrl.Manager) error
+	SetupWebhookWithManager(mgr ctrl.Manager) error
+}
+
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enableWebhooks bool
+	var imageCatalogPath string
+	var imagePullSecrets string
+	var limits benchmark.ConcurrencyLimits
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable the admission webhooks of the benchmark CRs. The webhook server requires a serving certificate.")
+	flag.StringVar(&imageCatalogPath, "image-catalog", "",
+		"Path of the YAML file overriding the default images, pull policies and pull secrets of the benchmarks.")
+	flag.StringVar(&imagePullSecrets, "image-pull-secrets", "",
+		"Comma separated list of pull secrets added to the pods of every benchmark.")
+	flag.IntVar(&limits.Global, "max-concurrent-benchmarks", 0,
+		"The maximum number of benchmarks running in the cluster at the same time. Zero means no limit.")
+	flag.IntVar(&limits.PerNamespace, "max-concurrent-benchmarks-per-namespace", 0,
+		"The maximum number of benchmarks running in a namespace at the same time. Zero means no limit.")
+	flag.IntVar(&limits.PerNode, "max-concurrent-benchmarks-per-node", 0,
+		"The maximum number of benchmarks pinned to a node (or node pool) at the same time. Zero means no limit.")
 	flag.Parse()
 
 	ctrl.SetLogger(zapr.NewLogger(rootLog))
@@ -84,104 +111,143 @@ func main() {
 		os.Exit(1)
 	}
 
+	images := benchmark.DefaultImageCatalog()
+	if imageCatalogPath != "" {
+		if images, err = benchmark.LoadImageCatalog(imageCatalogPath); err != nil {
+			setupLog.Error(err, "unable to load image catalog", "path", imageCatalogPath)
+			os.Exit(1)
+		}
+	}
+	if imagePullSecrets != "" {
+		images.PullSecrets = append(images.PullSecrets, strings.Split(imagePullSecrets, ",")...)
+	}
+
 	clientSet := kubernetes.NewForConfigOrDie(restClientConfig)
 	k8sAccess := k8s.Access{
 		Client:        mgr.GetClient(),
 		Clientset:     clientSet,
 		Scheme:        mgr.GetScheme(),
 		EventRecorder: k8s.NewEventRecorder(clientSet, rootLog.Sugar().Infof),
+		APIReader:     mgr.GetAPIReader(),
 	}
 
-	if err = (&iperf3.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Iperf3"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Iperf3")
-		os.Exit(1)
-	}
-	if err = (&fio.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Fio"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Fio")
-		os.Exit(1)
+	queue := benchmark.NewQueue(limits)
+	benchmarks := []struct {
+		name       string
+		reconciler benchmarkReconciler
+	}{
+		{"Iperf3", &iperf3.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Iperf3"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"Fio", &fio.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Fio"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"Sysbench", &sysbench.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Sysbench"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"Drill", &drill.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Drill"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"Pgbench", &pgbench.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Pgbench"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"Ioping", &ioping.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Ioping"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"Qperf", &qperf.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("Qperf"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"YcsbBench", &ycsbbench.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("YcsbBench"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"OcpLogtest", &ocplogtest.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("OcpLogtest"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"EsRally", &esrally.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("EsRally"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"S3Bench", &s3bench.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("S3Bench"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"KafkaBench", &kafkabench.KafkaBenchReconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("KafkaBench"),
+			Images: images,
+			Queue:  queue,
+		}},
+		{"JMeter", &jmeter.Reconciler{
+			K8S:    k8sAccess,
+			Log:    ctrl.Log.WithName("controllers").WithName("JMeter"),
+			Images: images,
+			Queue:  queue,
+		}},
 	}
-	if err = (&sysbench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Sysbench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Sysbench")
-		os.Exit(1)
-	}
-	if err = (&drill.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Drill"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Drill")
-		os.Exit(1)
-	}
-	if err = (&pgbench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Pgbench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Pgbench")
-		os.Exit(1)
+	for _, b := range benchmarks {
+		if err = b.reconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", b.name)
+			os.Exit(1)
+		}
+		if !enableWebhooks {
+			continue
+		}
+		if err = b.reconciler.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", b.name)
+			os.Exit(1)
+		}
 	}
-	if err = (&ioping.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Ioping"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Ioping")
-		os.Exit(1)
-	}
-	if err = (&qperf.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("Qperf"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Qperf")
-		os.Exit(1)
-	}
-	if err = (&ycsbbench.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("YcsbBench"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "YcsbBench")
-		os.Exit(1)
-	}
-	if err = (&ocplogtest.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("OcpLogtest"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "OcpLogtest")
-		os.Exit(1)
-	}
-	if err = (&esrally.Reconciler{
-		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("EsRally"),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "EsRally")
-		os.Exit(1)
-	}
-	if err = (&s3bench.Reconciler{
+
+	if err = (&benchmarksuite.Reconciler{
 		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("S3Bench"),
+		Log: ctrl.Log.WithName("controllers").WithName("BenchmarkSuite"),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "S3Bench")
+		setupLog.Error(err, "unable to create controller", "controller", "BenchmarkSuite")
 		os.Exit(1)
 	}
-
-	if err = (&kafkabench.KafkaBenchReconciler{
+	if err = (&benchmarkschedule.Reconciler{
 		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("KafkaBench"),
+		Log: ctrl.Log.WithName("controllers").WithName("BenchmarkSchedule"),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "KafkaBench")
+		setupLog.Error(err, "unable to create controller", "controller", "BenchmarkSchedule")
 		os.Exit(1)
 	}
-	if err = (&jmeter.Reconciler{
+	if err = (&benchmarkmatrix.Reconciler{
 		K8S: k8sAccess,
-		Log: ctrl.Log.WithName("controllers").WithName("JMeter"),
+		Log: ctrl.Log.WithName("controllers").WithName("BenchmarkMatrix"),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "JMeter")
+		setupLog.Error(err, "unable to create controller", "controller", "BenchmarkMatrix")
 		os.Exit(1)
 	}
 	// +kubebuilder:scaffold:builder
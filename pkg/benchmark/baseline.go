@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
+)
+
+// +kubebuilder:rbac:groups=perf.kubestone.xridge.io,resources=benchmarkbaselines,verbs=get;list;watch
+
+// CompareWithBaseline compares the results of the succeeded benchmark CR
+// with the BenchmarkBaseline selecting it, if any, and records the
+// verdict in the status of the CR and as an event. When more than one
+// baseline selects the CR, the first one by name is used.
+func CompareWithBaseline(ctx context.Context, access *k8s.Access, cr Object) error {
+	status := cr.GetBenchmarkStatus()
+	if status.Results == nil {
+		return nil
+	}
+
+	baseline, err := findBaseline(ctx, access, cr)
+	if err != nil || baseline == nil {
+		return err
+	}
+
+	baselineResults := baseline.Spec.Results
+	var baselineMetrics []perfv1alpha1.BenchmarkMetric
+	if baseline.Spec.BenchmarkRef != nil {
+		if baselineResults, err = referencedResults(ctx, access, baseline); err != nil {
+			return err
+		}
+		baselineMetrics = results.WithJobRoles(baselineResults.Metrics, baseline.Spec.BenchmarkRef.Name)
+	} else if baselineResults != nil {
+		// The stored results refer to the jobs by their role already
+		baselineMetrics = baselineResults.Metrics
+	} else {
+		return fmt.Errorf("baseline %s has neither benchmarkRef nor results", baseline.Name)
+	}
+
+	comparison, err := results.Compare(baseline, baselineMetrics,
+		results.WithJobRoles(status.Results.Metrics, cr.GetName()))
+	if err != nil {
+		return fmt.Errorf("unable to compare with baseline %s: %v", baseline.Name, err)
+	}
+	status.Baseline = comparison
+
+	eventType := corev1.EventTypeNormal
+	if comparison.Verdict == perfv1alpha1.BaselineRegressed {
+		eventType = corev1.EventTypeWarning
+	}
+	_ = access.RecordEventf(cr, eventType, k8s.BaselineCompared,
+		"Compared with baseline %s: %s%s", baseline.Name, comparison.Verdict, describeDeltas(comparison))
+	return nil
+}
+
+// findBaseline returns the first BenchmarkBaseline by name which selects the CR
+func findBaseline(ctx context.Context, access *k8s.Access, cr Object) (*perfv1alpha1.BenchmarkBaseline, error) {
+	gvk, err := apiutil.GVKForObject(cr, access.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	var baselines perfv1alpha1.BenchmarkBaselineList
+	if err := access.Client.List(ctx, &baselines, client.InNamespace(cr.GetNamespace())); err != nil {
+		return nil, err
+	}
+	sort.Slice(baselines.Items, func(i, j int) bool {
+		return baselines.Items[i].Name < baselines.Items[j].Name
+	})
+
+	for i := range baselines.Items {
+		baseline := &baselines.Items[i]
+		if baseline.Spec.Kind != gvk.Kind {
+			continue
+		}
+		// The benchmark CR of the baseline is not compared with itself
+		if baseline.Spec.BenchmarkRef != nil && baseline.Spec.BenchmarkRef.Name == cr.GetName() {
+			continue
+		}
+
+		selector := labels.Everything()
+		if baseline.Spec.Selector != nil {
+			if selector, err = metav1.LabelSelectorAsSelector(baseline.Spec.Selector); err != nil {
+				return nil, fmt.Errorf("invalid selector of baseline %s: %v", baseline.Name, err)
+			}
+		}
+		if selector.Matches(labels.Set(cr.GetLabels())) {
+			return baseline, nil
+		}
+	}
+	return nil, nil
+}
+
+// referencedResults returns the results of the succeeded benchmark CR
+// referred by the baseline
+func referencedResults(ctx context.Context, access *k8s.Access,
+	baseline *perfv1alpha1.BenchmarkBaseline) (*perfv1alpha1.BenchmarkResults, error) {
+	runtimeObject, err := access.Scheme.New(perfv1alpha1.GroupVersion.WithKind(baseline.Spec.Kind))
+	if err != nil {
+		return nil, err
+	}
+	reference, ok := runtimeObject.(Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a benchmark kind", baseline.Spec.Kind)
+	}
+
+	if err := access.Client.Get(ctx, types.NamespacedName{
+		Namespace: baseline.Namespace,
+		Name:      baseline.Spec.BenchmarkRef.Name,
+	}, reference); err != nil {
+		return nil, fmt.Errorf("unable to get the benchmark of baseline %s: %v", baseline.Name, err)
+	}
+
+	status := reference.GetBenchmarkStatus()
+	if status.Phase != perfv1alpha1.BenchmarkSucceeded || status.Results == nil {
+		return nil, fmt.Errorf("benchmark %s of baseline %s has no results",
+			reference.GetName(), baseline.Name)
+	}
+	return status.Results, nil
+}
+
+// describeDeltas lists the metrics which regressed or improved
+func describeDeltas(comparison *perfv1alpha1.BaselineComparison) string {
+	var deltas []string
+	for _, metric := range comparison.Metrics {
+		if metric.Verdict == perfv1alpha1.BaselinePass {
+			continue
+		}
+		name := metric.Name
+		if len(metric.Labels) > 0 {
+			name += "{" + labels.Set(metric.Labels).String() + "}"
+		}
+		deltas = append(deltas, fmt.Sprintf("%s %s%%", name, metric.Delta))
+	}
+	if len(deltas) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(deltas, ", ") + ")"
+}
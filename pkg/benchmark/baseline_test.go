@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Baseline events", func() {
+	It("should list the metrics which regressed or improved", func() {
+		comparison := perfv1alpha1.BaselineComparison{
+			Verdict: perfv1alpha1.BaselineRegressed,
+			Metrics: []perfv1alpha1.MetricComparison{
+				{Name: "iops", Labels: map[string]string{"rw": "read"}, Delta: "-20.00",
+					Verdict: perfv1alpha1.BaselineRegressed},
+				{Name: "iops", Labels: map[string]string{"rw": "write"}, Delta: "1.00",
+					Verdict: perfv1alpha1.BaselinePass},
+				{Name: "latency_seconds", Delta: "-10.00", Verdict: perfv1alpha1.BaselineImproved},
+			},
+		}
+		Expect(describeDeltas(&comparison)).To(Equal(" (iops{rw=read} -20.00%, latency_seconds -10.00%)"))
+	})
+
+	It("should not list anything when every metric passed", func() {
+		comparison := perfv1alpha1.BaselineComparison{Verdict: perfv1alpha1.BaselinePass}
+		Expect(describeDeltas(&comparison)).To(BeEmpty())
+	})
+})
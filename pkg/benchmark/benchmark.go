@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// Object is a benchmark custom resource driven by the Engine
+type Object interface {
+	runtime.Object
+	metav1.Object
+
+	// GetBenchmarkStatus returns the status of the benchmark, which is
+	// modified in place by the Engine
+	GetBenchmarkStatus() *perfv1alpha1.BenchmarkStatus
+
+	// GetBenchmarkSpec returns the fields of the spec common to every
+	// benchmark type (thresholds, sinks, timeout, cleanup, etc.)
+	GetBenchmarkSpec() *perfv1alpha1.BenchmarkSpec
+
+	// GetPodScheduling returns the scheduling of the pods of the benchmark
+	GetPodScheduling() []perfv1alpha1.PodSchedulingSpec
+}
+
+// ReadyFunc reports whether the objects of a step are ready, so the
+// Engine can proceed to the next step
+type ReadyFunc func() (bool, error)
+
+// Step is a group of Kubernetes objects the benchmark needs, which are
+// created together and optionally waited for before the next step starts.
+type Step struct {
+	// Objects are created with the benchmark CR as their controller
+	Objects []metav1.Object
+
+	// Build returns objects which can only be built once the previous
+	// steps are ready (e.g. they need the IP address of a pod). They are
+	// created along with Objects.
+	Build func() ([]metav1.Object, error)
+
+	// Ready gates the next step. Steps without Ready are not waited for.
+	Ready ReadyFunc
+
+	// Poll has to be set when the readiness depends on objects which are
+	// not owned by the CR (e.g. Endpoints or the pods of a Job) and
+	// therefore their changes do not trigger a reconciliation.
+	Poll bool
+}
+
+// Benchmark describes how a benchmark type is executed by the Engine.
+// The Jobs created in the steps are the ones executing the benchmark:
+// the benchmark is finished when all of them are finished.
+type Benchmark interface {
+	// NewObject returns an empty CR of the benchmark type
+	NewObject() Object
+
+	// Validate checks the CR for semantic errors before any object is created
+	Validate(cr Object) error
+
+	// Steps returns the objects of the benchmark in creation order
+	Steps(cr Object) ([]Step, error)
+}
+
+// Defaulter is implemented by the benchmarks whose CRs have optional
+// fields defaulted by the operator (e.g. the images of the ImageCatalog).
+// Defaults are applied before every reconciliation and are not written to
+// the spec, so they are not part of the hash of the spec (see specHash).
+// The resulting images are recorded in the status instead.
+type Defaulter interface {
+	Default(cr Object)
+}
+
+// ReferenceValidator is implemented by the benchmarks whose CRs refer to
+// other objects (e.g. the Secrets of credentials). The references are
+// checked by the Engine before the benchmark starts, but not by the
+// validating webhook, so the CRs can still be updated (e.g. annotated)
+// after a referred object has been deleted.
+type ReferenceValidator interface {
+	ValidateReferences(cr Object) error
+}
+
+// ResultCollector is implemented by the benchmarks whose output can be
+// parsed into metrics once the benchmark jobs have succeeded
+type ResultCollector interface {
+	CollectResults(cr Object, job *batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error)
+}
+
+// Cleaner is implemented by the benchmarks which need to remove some of
+// their objects (e.g. server deployments) once the benchmark jobs are finished
+type Cleaner interface {
+	Cleanup(ctx context.Context, cr Object) error
+}
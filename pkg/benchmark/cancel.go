@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// CancelAnnotation cancels the running benchmark when set to "true"
+const CancelAnnotation = "kubestone.xridge.io/cancel"
+
+// CancelledReason is the reason of the benchmarks which have been
+// cancelled or suspended before completion
+const CancelledReason = "Cancelled"
+
+// isCancelled returns true if the benchmark is suspended or cancelled
+func isCancelled(cr Object) bool {
+	return cr.GetBenchmarkSpec().Suspend || cr.GetAnnotations()[CancelAnnotation] == "true"
+}
+
+// cancel stops the running benchmark: the logs are archived, the jobs
+// and their pods are deleted and the servers are scaled down, then the
+// benchmark is moved to the Cancelled phase
+func (e *Engine) cancel(ctx context.Context, req ctrl.Request, b Benchmark, cr Object) (ctrl.Result, error) {
+	logs, archive := e.archiveLogs(ctx, cr)
+	environment := e.fingerprint(ctx, cr)
+
+	if err := e.K8S.DeleteControlledObjects(ctx, cr, isJob); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := e.K8S.ScaleDownControlledObjects(ctx, cr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if cleaner, ok := b.(Cleaner); ok {
+		if err := cleaner.Cleanup(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := e.K8S.Client.Get(ctx, req.NamespacedName, cr); err != nil {
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+	status := cr.GetBenchmarkStatus()
+	status.Logs = archive
+	status.Environment = environment
+
+	message := "Benchmark has been cancelled"
+	if cr.GetBenchmarkSpec().Suspend {
+		message = "Benchmark has been suspended"
+	}
+	_ = e.K8S.RecordEventf(cr, corev1.EventTypeNormal, k8s.Cancelled, message)
+	status.MarkCancelled(CancelledReason, message)
+	if len(cr.GetBenchmarkSpec().Thresholds) > 0 {
+		status.SetCondition(perfv1alpha1.BenchmarkConditionPassed, corev1.ConditionFalse,
+			"BenchmarkCancelled", message)
+	}
+	return e.complete(ctx, cr, logs)
+}
+
+// isJob returns true for Jobs
+func isJob(object metav1.Object) bool {
+	_, ok := object.(*batchv1.Job)
+	return ok
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Cancel", func() {
+	var cr *perfv1alpha1.JMeter
+
+	BeforeEach(func() {
+		cr = &perfv1alpha1.JMeter{
+			ObjectMeta: metav1.ObjectMeta{Name: "jmeter-sample", Generation: 1},
+			Status: perfv1alpha1.BenchmarkStatus{
+				Phase:              perfv1alpha1.BenchmarkRunning,
+				Run:                1,
+				ObservedGeneration: 1,
+			},
+		}
+	})
+
+	It("should cancel the suspended benchmarks", func() {
+		Expect(isCancelled(cr)).To(BeFalse())
+		cr.Spec.Suspend = true
+		Expect(isCancelled(cr)).To(BeTrue())
+	})
+
+	It("should cancel the benchmarks with the cancel annotation", func() {
+		cr.Annotations = map[string]string{CancelAnnotation: "false"}
+		Expect(isCancelled(cr)).To(BeFalse())
+		cr.Annotations[CancelAnnotation] = "true"
+		Expect(isCancelled(cr)).To(BeTrue())
+	})
+
+	It("should move the benchmark to the Cancelled phase", func() {
+		cr.Status.MarkCancelled(CancelledReason, "Benchmark has been cancelled")
+		Expect(cr.Status.Finished()).To(BeTrue())
+		Expect(cr.Status.CompletionTime).NotTo(BeNil())
+		Expect(cr.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionComplete)).To(BeFalse())
+	})
+
+	It("should resume the cancelled benchmark with a new run", func() {
+		cr.Spec.Suspend = true
+		cr.Generation = 2
+		cr.Status.MarkCancelled(CancelledReason, "Benchmark has been suspended")
+		Expect(rerunReason(cr)).To(BeEmpty())
+
+		cr.Spec.Suspend = false
+		cr.Generation = 3
+		Expect(rerunReason(cr)).NotTo(BeEmpty())
+	})
+
+	It("should stop only the jobs", func() {
+		Expect(isJob(&batchv1.Job{})).To(BeTrue())
+		Expect(isJob(&corev1.Service{})).To(BeFalse())
+	})
+})
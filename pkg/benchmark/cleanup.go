@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/sinks"
+)
+
+// cleanUp deletes the objects of the finished benchmark according to its
+// cleanup policy, once its TTL has expired. The objects holding the
+// results and the logs of the benchmark are kept along with the CR.
+func (e *Engine) cleanUp(ctx context.Context, cr Object) (ctrl.Result, error) {
+	status := cr.GetBenchmarkStatus()
+	if !shouldCleanUp(cr) || status.GetCondition(perfv1alpha1.BenchmarkConditionCleanedUp) != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if ttl := cr.GetBenchmarkSpec().TTLSecondsAfterFinished; ttl != nil && status.CompletionTime != nil {
+		expiry := status.CompletionTime.Add(time.Duration(*ttl) * time.Second)
+		if remaining := time.Until(expiry); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	if err := e.K8S.DeleteControlledObjects(ctx, cr, isDisposable); err != nil {
+		return ctrl.Result{}, err
+	}
+	status.SetCondition(perfv1alpha1.BenchmarkConditionCleanedUp, corev1.ConditionTrue,
+		string(cleanupPolicy(cr)), "The objects of the benchmark have been deleted")
+	return ctrl.Result{}, e.K8S.Client.Status().Update(ctx, cr)
+}
+
+// cleanupPolicy returns the cleanup policy of the CR: Never by default,
+// Always if only the TTL is given
+func cleanupPolicy(cr Object) perfv1alpha1.CleanupPolicy {
+	if policy := cr.GetBenchmarkSpec().CleanupPolicy; policy != "" {
+		return policy
+	}
+	if cr.GetBenchmarkSpec().TTLSecondsAfterFinished != nil {
+		return perfv1alpha1.CleanupAlways
+	}
+	return perfv1alpha1.CleanupNever
+}
+
+// shouldCleanUp returns true if the objects of the finished benchmark
+// have to be deleted
+func shouldCleanUp(cr Object) bool {
+	switch cleanupPolicy(cr) {
+	case perfv1alpha1.CleanupAlways:
+		return true
+	case perfv1alpha1.CleanupOnSuccess:
+		return cr.GetBenchmarkStatus().Phase == perfv1alpha1.BenchmarkSucceeded
+	}
+	return false
+}
+
+// isDisposable returns false for the objects holding results or logs
+func isDisposable(object metav1.Object) bool {
+	_, results := object.GetLabels()[sinks.ResultsLabel]
+	return !results
+}
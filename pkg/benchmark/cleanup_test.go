@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/sinks"
+)
+
+var _ = Describe("Cleanup", func() {
+	var cr *perfv1alpha1.Fio
+
+	BeforeEach(func() {
+		cr = &perfv1alpha1.Fio{
+			Status: perfv1alpha1.BenchmarkStatus{Phase: perfv1alpha1.BenchmarkFailed},
+		}
+	})
+
+	It("should keep the objects by default", func() {
+		Expect(cleanupPolicy(cr)).To(Equal(perfv1alpha1.CleanupNever))
+		Expect(shouldCleanUp(cr)).To(BeFalse())
+	})
+
+	It("should always clean up when only the TTL is given", func() {
+		ttl := int32(3600)
+		cr.Spec.TTLSecondsAfterFinished = &ttl
+		Expect(cleanupPolicy(cr)).To(Equal(perfv1alpha1.CleanupAlways))
+		Expect(shouldCleanUp(cr)).To(BeTrue())
+	})
+
+	It("should clean up the succeeded benchmarks only with OnSuccess", func() {
+		cr.Spec.CleanupPolicy = perfv1alpha1.CleanupOnSuccess
+		Expect(shouldCleanUp(cr)).To(BeFalse())
+
+		cr.Status.Phase = perfv1alpha1.BenchmarkSucceeded
+		Expect(shouldCleanUp(cr)).To(BeTrue())
+	})
+
+	It("should keep the objects holding the results", func() {
+		logs := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:   "fio-sample-logs",
+			Labels: map[string]string{sinks.ResultsLabel: "true"},
+		}}
+		Expect(isDisposable(&logs)).To(BeFalse())
+
+		jobFiles := corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "fio-sample"}}
+		Expect(isDisposable(&jobFiles)).To(BeTrue())
+	})
+})
@@ -0,0 +1,478 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
+	"github.com/xridge/kubestone/pkg/sinks"
+)
+
+// Engine drives the lifecycle of a benchmark CR: it validates the CR,
+// creates the objects of the benchmark step by step, waits for the
+// benchmark jobs to finish and records the outcome in the status.
+type Engine struct {
+	K8S *k8s.Access
+	Log logr.Logger
+	// Queue admits the benchmarks, all of them start right away if nil
+	Queue *Queue
+	// Images are the image defaults of the operator, used for the images
+	// of the helper jobs (e.g. the job of the PVC sink) as well
+	Images *ImageCatalog
+}
+
+// Reconcile brings the benchmark CR referred by the request one step
+// closer to completion
+func (e *Engine) Reconcile(req ctrl.Request, b Benchmark) (ctrl.Result, error) {
+	ctx := context.Background()
+
+	cr := b.NewObject()
+	if err := e.K8S.Client.Get(ctx, req.NamespacedName, cr); err != nil {
+		if errors.IsNotFound(err) {
+			e.unexportResults(b, req.NamespacedName)
+		}
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+
+	status := cr.GetBenchmarkStatus()
+	if status.Finished() {
+		if reason := rerunReason(cr); reason != "" {
+			return e.rerun(ctx, cr, reason)
+		}
+		if hasPendingDeliveries(cr) {
+			if err := e.trackDeliveries(ctx, cr); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		// The results are exported again after a restart of the operator
+		e.exportResults(cr)
+		return e.cleanUp(ctx, cr)
+	}
+
+	// Accept the CR on first entry. The spec is hashed as written by the
+	// user, without the defaults.
+	if status.Phase == "" {
+		status.ObservedGeneration = cr.GetGeneration()
+		status.ObservedRerun = cr.GetAnnotations()[RerunAnnotation]
+		status.ObservedSpecHash = specHash(cr)
+		if status.Run == 0 {
+			status.Run = 1
+		}
+		status.SetPhase(perfv1alpha1.BenchmarkPending, "Benchmark accepted")
+		return ctrl.Result{Requeue: true}, e.K8S.Client.Status().Update(ctx, cr)
+	}
+
+	// A CR left in Validating by a restart of the operator is validated again
+	validating := status.Phase == perfv1alpha1.BenchmarkPending || status.Phase == perfv1alpha1.BenchmarkValidating
+	if validating {
+		status.SetPhase(perfv1alpha1.BenchmarkValidating, "Validating the benchmark")
+		if err := e.K8S.Client.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if defaulter, ok := b.(Defaulter); ok {
+		defaulter.Default(cr)
+	}
+
+	if validating {
+		err := validate(b, cr)
+		if referenceValidator, ok := b.(ReferenceValidator); ok && err == nil {
+			err = referenceValidator.ValidateReferences(cr)
+		}
+		if err != nil {
+			_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.CreateFailed,
+				"CR validation failed: %v", err)
+			status.SetCondition(perfv1alpha1.BenchmarkConditionValid, corev1.ConditionFalse,
+				"ValidationFailed", err.Error())
+			status.MarkFailed("ValidationFailed", "CR validation failed: "+err.Error())
+
+			// Do not requeue invalid CRs
+			return ctrl.Result{}, e.K8S.Client.Status().Update(ctx, cr)
+		}
+
+		status.SetCondition(perfv1alpha1.BenchmarkConditionValid, corev1.ConditionTrue,
+			"ValidationSucceeded", "")
+		status.Images = imagesOf(cr)
+	}
+
+	if isCancelled(cr) {
+		return e.cancel(ctx, req, b, cr)
+	}
+
+	if status.Phase == perfv1alpha1.BenchmarkValidating || status.Phase == perfv1alpha1.BenchmarkQueued {
+		if result, queued, err := e.admit(ctx, cr); queued || err != nil {
+			return result, err
+		}
+		if fanOut := fanOutOf(cr); fanOut != nil {
+			nodes, err := e.fanOutNodes(fanOut)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if len(nodes) == 0 {
+				message := "No ready and schedulable nodes match the fan-out node selector"
+				_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.Failed, message)
+				markFailed(cr, "NoMatchingNodes", message)
+				return e.complete(ctx, cr, nil)
+			}
+			status.FanOut = newFanOutStatus(nodes)
+		}
+		status.SetPhase(perfv1alpha1.BenchmarkProvisioning, "Creating benchmark resources")
+		if err := e.K8S.Client.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	deadline := deadlineOf(cr)
+	if deadline != nil && !time.Now().Before(*deadline) {
+		return e.timeOut(ctx, req, b, cr)
+	}
+
+	steps, err := b.Steps(cr)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var jobs []*batchv1.Job
+	for _, step := range steps {
+		objects := step.Objects
+		if step.Build != nil {
+			built, err := step.Build()
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			objects = append(append(objects[:0:0], objects...), built...)
+		}
+
+		for _, object := range objects {
+			if job, ok := object.(*batchv1.Job); ok {
+				// The jobs of the previous runs may still be terminating
+				job.Name = perfv1alpha1.RunName(job.Name, status.Run)
+				if deadline != nil {
+					setActiveDeadline(job, *deadline)
+				}
+				if status.FanOut != nil {
+					nodeJobs, err := e.createFanOutJobs(ctx, cr, job)
+					if err != nil {
+						return ctrl.Result{}, err
+					}
+					jobs = append(jobs, nodeJobs...)
+					continue
+				}
+			}
+			if err := e.K8S.CreateWithReference(ctx, object, cr); err != nil {
+				return ctrl.Result{}, err
+			}
+			if job, ok := object.(*batchv1.Job); ok {
+				jobs = append(jobs, job)
+			}
+		}
+
+		if step.Ready == nil {
+			continue
+		}
+		ready, err := step.Ready()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if ready {
+			continue
+		}
+
+		// A failed job will never make the following steps ready, so bail out early
+		outcomes, err := e.jobOutcomes(jobs)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(failedOutcomes(outcomes)) > 0 {
+			return e.finish(ctx, req, b, cr, jobs, outcomes)
+		}
+
+		if step.Poll {
+			return waitUntil(ctrl.Result{RequeueAfter: k8s.RequeueDelay(status.StartTime)}, deadline), nil
+		}
+		// The watches on the owned objects trigger a new reconciliation
+		return waitUntil(ctrl.Result{}, deadline), nil
+	}
+
+	if status.Phase == perfv1alpha1.BenchmarkProvisioning {
+		status.SetPhase(perfv1alpha1.BenchmarkRunning, "Benchmark is running")
+		if err := e.K8S.Client.Status().Update(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	outcomes, err := e.jobOutcomes(jobs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, outcome := range outcomes {
+		if !outcome.Finished() {
+			// Wait for the jobs to be completed, the Job watch triggers
+			// a new reconciliation when their status changes
+			return waitUntil(ctrl.Result{}, deadline), nil
+		}
+	}
+
+	return e.finish(ctx, req, b, cr, jobs, outcomes)
+}
+
+// finish cleans up after the benchmark and moves it to a terminal phase
+// based on the outcome of its jobs
+func (e *Engine) finish(ctx context.Context, req ctrl.Request, b Benchmark, cr Object,
+	jobs []*batchv1.Job, outcomes []k8s.JobOutcome) (ctrl.Result, error) {
+	failed := failedOutcomes(outcomes)
+	if len(failed) > 0 && failed[0].Reason == jobDeadlineExceeded && deadlineOf(cr) != nil {
+		return e.timeOut(ctx, req, b, cr)
+	}
+
+	// The logs and the nodes are captured before the cleanup removes the server side pods
+	logs, archive := e.archiveLogs(ctx, cr)
+	environment := e.fingerprint(ctx, cr)
+
+	if cleaner, ok := b.(Cleaner); ok {
+		if err := cleaner.Cleanup(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The cr could have been modified since the last time we got it
+	if err := e.K8S.Client.Get(ctx, req.NamespacedName, cr); err != nil {
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+	status := cr.GetBenchmarkStatus()
+	status.Logs = archive
+	status.Environment = environment
+	if status.FanOut != nil {
+		recordFanOut(status, jobs, outcomes)
+	}
+
+	if len(failed) > 0 {
+		message := "Benchmark job failed: " + failed[0].Message
+		if len(jobs) > 1 {
+			message = fmt.Sprintf("%d benchmark job(s) failed: %v", len(failed), failed[0].Message)
+		}
+		markFailed(cr, failed[0].Reason, message)
+		for _, outcome := range failed {
+			_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.Failed,
+				"Benchmark job failed: %v: %v", outcome.Reason, outcome.Message)
+		}
+	} else {
+		if collector, ok := b.(ResultCollector); ok {
+			metrics, err := collectResults(collector, cr, jobs)
+			if err != nil {
+				e.Log.Error(err, "Unable to collect benchmark results")
+				_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.ResultCollectionFailed,
+					"Failed to collect benchmark results: %v", err)
+			} else {
+				status.Results = results.NewResults(metrics)
+			}
+		}
+
+		if status.FanOut != nil {
+			if err := findOutliers(cr); err != nil {
+				e.Log.Error(err, "Unable to find the outliers")
+			}
+		}
+
+		if err := CompareWithBaseline(ctx, e.K8S, cr); err != nil {
+			e.Log.Error(err, "Unable to compare with the baseline")
+			_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.BaselineComparisonFailed,
+				"Failed to compare with the baseline: %v", err)
+		}
+
+		if len(cr.GetBenchmarkSpec().Thresholds) > 0 {
+			e.evaluateThresholds(cr)
+		}
+
+		message := "Benchmark job completed"
+		if len(jobs) > 1 {
+			message = "Benchmark jobs completed"
+		}
+		status.MarkSucceeded(message)
+	}
+
+	return e.complete(ctx, cr, logs)
+}
+
+// timeOut removes the objects of the benchmark which has not finished
+// within its timeout and moves it to the Failed phase
+func (e *Engine) timeOut(ctx context.Context, req ctrl.Request, b Benchmark, cr Object) (ctrl.Result, error) {
+	logs, archive := e.archiveLogs(ctx, cr)
+	environment := e.fingerprint(ctx, cr)
+
+	if err := e.K8S.DeleteControlledObjects(ctx, cr, k8s.IsWorkload); err != nil {
+		return ctrl.Result{}, err
+	}
+	if cleaner, ok := b.(Cleaner); ok {
+		if err := cleaner.Cleanup(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := e.K8S.Client.Get(ctx, req.NamespacedName, cr); err != nil {
+		return ctrl.Result{}, k8s.IgnoreNotFound(err)
+	}
+	cr.GetBenchmarkStatus().Logs = archive
+	cr.GetBenchmarkStatus().Environment = environment
+
+	message := fmt.Sprintf("Benchmark has not finished within %v", cr.GetBenchmarkSpec().Timeout.Duration)
+	_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.TimedOut, message)
+	markFailed(cr, TimedOutReason, message)
+	return e.complete(ctx, cr, logs)
+}
+
+// complete persists the status of the finished benchmark, delivers its
+// results to the sinks and exports them, then cleans up after the benchmark
+func (e *Engine) complete(ctx context.Context, cr Object, logs map[string]string) (ctrl.Result, error) {
+	deliver := len(cr.GetBenchmarkSpec().Sinks) > 0
+	if deliver {
+		// The finished status is persisted before the delivery, so a
+		// retried reconciliation does not deliver the results again
+		cr.GetBenchmarkStatus().SetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered,
+			corev1.ConditionUnknown, "Delivering", "The results are being delivered to the sinks")
+	}
+
+	if err := e.K8S.Client.Status().Update(ctx, cr); err != nil {
+		return ctrl.Result{}, err
+	}
+	if deliver {
+		e.deliverResults(ctx, cr, logs)
+		if err := e.recordDeliveries(ctx, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	e.exportResults(cr)
+	return e.cleanUp(ctx, cr)
+}
+
+// markFailed moves the benchmark to the Failed phase. Failed benchmarks
+// with thresholds have not passed.
+func markFailed(cr Object, reason, message string) {
+	status := cr.GetBenchmarkStatus()
+	status.MarkFailed(reason, message)
+	if len(cr.GetBenchmarkSpec().Thresholds) > 0 {
+		status.SetCondition(perfv1alpha1.BenchmarkConditionPassed, corev1.ConditionFalse,
+			"BenchmarkFailed", message)
+	}
+}
+
+// evaluateThresholds checks the results of the succeeded benchmark against
+// the thresholds of the CR and records the outcome in the Passed condition
+func (e *Engine) evaluateThresholds(cr Object) {
+	status := cr.GetBenchmarkStatus()
+	if status.Results == nil {
+		status.SetCondition(perfv1alpha1.BenchmarkConditionPassed, corev1.ConditionFalse,
+			"NoResults", "No results were collected to evaluate the thresholds")
+		return
+	}
+
+	violations, err := results.EvaluateThresholds(cr.GetBenchmarkSpec().Thresholds, status.Results.Metrics)
+	if err != nil {
+		status.SetCondition(perfv1alpha1.BenchmarkConditionPassed, corev1.ConditionFalse,
+			"EvaluationFailed", err.Error())
+		return
+	}
+	if len(violations) > 0 {
+		message := "Thresholds violated: " + strings.Join(violations, ", ")
+		_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.ThresholdsViolated, message)
+		status.SetCondition(perfv1alpha1.BenchmarkConditionPassed, corev1.ConditionFalse,
+			"ThresholdsViolated", message)
+		return
+	}
+	status.SetCondition(perfv1alpha1.BenchmarkConditionPassed, corev1.ConditionTrue,
+		"ThresholdsSatisfied", "All thresholds are satisfied")
+}
+
+// validate runs the validation of the benchmark and checks the common
+// fields of the CR: thresholds, timeout, fan-out and sinks
+func validate(b Benchmark, cr Object) error {
+	if err := b.Validate(cr); err != nil {
+		return err
+	}
+	if err := results.ValidateThresholds(cr.GetBenchmarkSpec().Thresholds); err != nil {
+		return err
+	}
+	if timeout := cr.GetBenchmarkSpec().Timeout; timeout != nil && timeout.Duration <= 0 {
+		return fmt.Errorf("timeout has to be positive: %v", timeout.Duration)
+	}
+	if err := validateFanOut(cr); err != nil {
+		return err
+	}
+	return sinks.Validate(cr.GetBenchmarkSpec().Sinks)
+}
+
+// jobOutcomes returns the outcome of each job. Jobs which are not
+// visible yet are reported as running.
+func (e *Engine) jobOutcomes(jobs []*batchv1.Job) ([]k8s.JobOutcome, error) {
+	outcomes := make([]k8s.JobOutcome, 0, len(jobs))
+	for _, job := range jobs {
+		outcome, err := e.K8S.GetJobOutcome(types.NamespacedName{
+			Namespace: job.Namespace,
+			Name:      job.Name,
+		})
+		if errors.IsNotFound(err) {
+			outcome, err = k8s.JobOutcome{Phase: k8s.JobRunning}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, nil
+}
+
+func failedOutcomes(outcomes []k8s.JobOutcome) (failed []k8s.JobOutcome) {
+	for _, outcome := range outcomes {
+		if outcome.Phase == k8s.JobFailed {
+			failed = append(failed, outcome)
+		}
+	}
+	return failed
+}
+
+func collectResults(collector ResultCollector, cr Object, jobs []*batchv1.Job) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	for _, job := range jobs {
+		jobMetrics, err := collector.CollectResults(cr, job)
+		if err != nil {
+			return nil, err
+		}
+		if node, ok := job.Annotations[FanOutNodeAnnotation]; ok {
+			jobMetrics = results.WithLabels(jobMetrics,
+				map[string]string{perfv1alpha1.FanOutNodeLabel: node})
+		}
+		for i := range jobMetrics {
+			jobMetrics[i].Job = job.Name
+		}
+		metrics = append(metrics, jobMetrics...)
+	}
+	return metrics, nil
+}
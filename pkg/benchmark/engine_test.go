@@ -0,0 +1,400 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// jobBenchmark runs the Fio CRs with a single job
+type jobBenchmark struct {
+	fioBenchmark
+}
+
+func (jobBenchmark) Steps(cr Object) ([]Step, error) {
+	job := k8s.NewPerfJob(metav1.ObjectMeta{Name: cr.GetName(), Namespace: cr.GetNamespace()},
+		"fio", perfv1alpha1.ImageSpec{Name: "xridge/fio:test"}, perfv1alpha1.PodConfigurationSpec{})
+	return []Step{{Objects: []metav1.Object{job}}}, nil
+}
+
+var _ = Describe("Engine", func() {
+	const namespace = "kubestone"
+	name := types.NamespacedName{Namespace: namespace, Name: "fio-sample"}
+
+	var cr *perfv1alpha1.Fio
+	var engine *Engine
+	var clientset *fakeclientset.Clientset
+
+	// reconcile runs the engine on the CR and returns its persisted state
+	reconcile := func() (ctrl.Result, *perfv1alpha1.Fio) {
+		if engine == nil {
+			scheme := runtime.NewScheme()
+			Expect(k8sscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(perfv1alpha1.AddToScheme(scheme)).To(Succeed())
+			clientset = fakeclientset.NewSimpleClientset()
+			fakeClient := fake.NewFakeClientWithScheme(scheme, cr)
+			engine = &Engine{
+				K8S: &k8s.Access{
+					Client:        fakeClient,
+					Clientset:     clientset,
+					Scheme:        scheme,
+					EventRecorder: record.NewFakeRecorder(100),
+					APIReader:     fakeClient,
+				},
+				Log: ctrl.Log.WithName("engine"),
+			}
+		}
+
+		result, err := engine.Reconcile(ctrl.Request{NamespacedName: name}, jobBenchmark{})
+		Expect(err).NotTo(HaveOccurred())
+		persisted := &perfv1alpha1.Fio{}
+		Expect(engine.K8S.Client.Get(context.Background(), name, persisted)).To(Succeed())
+		return result, persisted
+	}
+
+	// start accepts the CR and runs the engine on the pending CR
+	start := func() (ctrl.Result, *perfv1alpha1.Fio) {
+		_, accepted := reconcile()
+		Expect(accepted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkPending))
+		return reconcile()
+	}
+
+	// getJob returns the job of the benchmark, or nil if it does not exist
+	getJob := func() *batchv1.Job {
+		job := &batchv1.Job{}
+		err := engine.K8S.Client.Get(context.Background(), name, job)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return job
+	}
+
+	// finishJob makes the job of the benchmark finished with the given condition
+	finishJob := func(conditionType batchv1.JobConditionType, reason string) {
+		job := getJob()
+		Expect(job).NotTo(BeNil())
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: conditionType, Status: corev1.ConditionTrue, Reason: reason, Message: reason},
+		}
+		_, err := clientset.BatchV1().Jobs(namespace).Create(job)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		engine = nil
+		cr = &perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name.Name,
+				Namespace:  name.Namespace,
+				UID:        "fio-sample-uid",
+				Generation: 1,
+			},
+			Spec: perfv1alpha1.FioSpec{
+				BuiltinJobFiles: []string{"/jobs/rand-read.fio"},
+			},
+		}
+		// The status updates of the fake client persist the whole CR,
+		// so the spec is created with its defaults already applied
+		fioBenchmark{}.Default(cr)
+	})
+
+	Context("with an invalid CR", func() {
+		It("should fail the benchmark without creating its objects", func() {
+			cr.Spec.BuiltinJobFiles = nil
+			result, persisted := start()
+			Expect(result).To(Equal(ctrl.Result{}))
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionValid)).To(BeFalse())
+			Expect(persisted.Status.Message).To(ContainSubstring("no builtin job files"))
+			Expect(getJob()).To(BeNil())
+		})
+
+		It("should fail the benchmark referring to a missing object", func() {
+			reconcile()
+			_, err := engine.Reconcile(ctrl.Request{NamespacedName: name}, referringBenchmark{})
+			Expect(err).NotTo(HaveOccurred())
+			persisted := &perfv1alpha1.Fio{}
+			Expect(engine.K8S.Client.Get(context.Background(), name, persisted)).To(Succeed())
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+			Expect(persisted.Status.Message).To(ContainSubstring(`secrets "credentials" not found`))
+			Expect(getJob()).To(BeNil())
+		})
+	})
+
+	Context("with a new CR", func() {
+		It("should accept the CR as pending without creating its objects", func() {
+			result, persisted := reconcile()
+			Expect(result.Requeue).To(BeTrue())
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkPending))
+			Expect(persisted.Status.Run).To(Equal(int32(1)))
+			Expect(persisted.Status.StartTime).To(BeNil())
+			Expect(getJob()).To(BeNil())
+		})
+
+		It("should validate a CR left in the Validating phase again", func() {
+			cr.Spec.BuiltinJobFiles = nil
+			cr.Status.SetPhase(perfv1alpha1.BenchmarkValidating, "Validating the benchmark")
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionValid)).To(BeFalse())
+		})
+	})
+
+	Context("with a valid CR", func() {
+		It("should persist the status of the started benchmark", func() {
+			_, persisted := start()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkRunning))
+			Expect(persisted.Status.Run).To(Equal(int32(1)))
+			Expect(persisted.Status.ObservedGeneration).To(Equal(int64(1)))
+			Expect(persisted.Status.ObservedSpecHash).To(Equal(specHash(cr)))
+			Expect(persisted.Status.Images).To(Equal([]string{"xridge/fio:3.13"}))
+			Expect(persisted.Status.StartTime).NotTo(BeNil())
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionValid)).To(BeTrue())
+
+			job := getJob()
+			Expect(job).NotTo(BeNil())
+			Expect(metav1.IsControlledBy(job, cr)).To(BeTrue())
+		})
+
+		It("should keep running until the job is finished", func() {
+			start()
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkRunning))
+			Expect(persisted.Status.CompletionTime).To(BeNil())
+		})
+
+		It("should succeed when the job succeeds", func() {
+			start()
+			finishJob(batchv1.JobComplete, "")
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkSucceeded))
+			Expect(persisted.Status.CompletionTime).NotTo(BeNil())
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionComplete)).To(BeTrue())
+			Expect(getJob()).NotTo(BeNil())
+		})
+
+		It("should fail when the job fails", func() {
+			start()
+			finishJob(batchv1.JobFailed, "BackoffLimitExceeded")
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+			Expect(persisted.Status.Message).To(ContainSubstring("BackoffLimitExceeded"))
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionFailed)).To(BeTrue())
+		})
+	})
+
+	Context("with a timeout", func() {
+		It("should fail the benchmark and delete its job once the timeout expires", func() {
+			cr.Spec.Timeout = &metav1.Duration{Duration: time.Minute}
+			start()
+
+			// Move the start of the benchmark before the timeout
+			started := &perfv1alpha1.Fio{}
+			Expect(engine.K8S.Client.Get(context.Background(), name, started)).To(Succeed())
+			startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+			started.Status.StartTime = &startTime
+			Expect(engine.K8S.Client.Status().Update(context.Background(), started)).To(Succeed())
+
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+			Expect(persisted.Status.GetCondition(perfv1alpha1.BenchmarkConditionFailed).Reason).To(
+				Equal(TimedOutReason))
+			Expect(getJob()).To(BeNil())
+		})
+
+		It("should requeue the running benchmark before the timeout", func() {
+			cr.Spec.Timeout = &metav1.Duration{Duration: time.Hour}
+			result, _ := start()
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour+time.Second))
+			Expect(*getJob().Spec.ActiveDeadlineSeconds).To(BeNumerically("<=", 3600))
+		})
+	})
+
+	Context("with the Always cleanup policy", func() {
+		It("should delete the job of the finished benchmark", func() {
+			cr.Spec.CleanupPolicy = perfv1alpha1.CleanupAlways
+			start()
+			finishJob(batchv1.JobComplete, "")
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkSucceeded))
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionCleanedUp)).To(BeTrue())
+			Expect(getJob()).To(BeNil())
+		})
+	})
+
+	Context("with a sink", func() {
+		It("should deliver the results once when recording the delivery conflicts", func() {
+			cr.Spec.Sinks = []perfv1alpha1.ResultSink{{ConfigMap: &perfv1alpha1.ConfigMapSink{}}}
+			start()
+			finishJob(batchv1.JobComplete, "")
+			engine.K8S.Client = &conflictingClient{Client: engine.K8S.Client}
+			reconcile()
+			_, persisted := reconcile()
+
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkSucceeded))
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionResultsDelivered)).To(BeTrue())
+			Expect(persisted.Status.Sinks).To(HaveLen(1))
+			Expect(persisted.Status.Sinks[0].Error).To(BeEmpty())
+
+			Expect(engine.K8S.Client.(*conflictingClient).conflicted).To(BeTrue())
+			Expect(engine.K8S.Client.(*conflictingClient).deliveries).To(Equal(1))
+			results := &corev1.ConfigMap{}
+			Expect(engine.K8S.Client.Get(context.Background(),
+				types.NamespacedName{Namespace: namespace, Name: "fio-sample-results"}, results)).To(Succeed())
+		})
+	})
+
+	Context("with a PVC sink", func() {
+		// finishCopyJob makes the job of the PVC sink finished with the given condition
+		finishCopyJob := func(conditionType batchv1.JobConditionType, message string) {
+			job := &batchv1.Job{}
+			Expect(engine.K8S.Client.Get(context.Background(),
+				types.NamespacedName{Namespace: namespace, Name: "fio-sample-sink-0"}, job)).To(Succeed())
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: conditionType, Status: corev1.ConditionTrue, Message: message},
+			}
+			_, err := clientset.BatchV1().Jobs(namespace).Create(job)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			cr.Spec.Sinks = []perfv1alpha1.ResultSink{{PVC: &perfv1alpha1.PVCSink{ClaimName: "results"}}}
+		})
+
+		It("should report the delivery once the copy job has succeeded", func() {
+			start()
+			finishJob(batchv1.JobComplete, "")
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkSucceeded))
+			Expect(persisted.Status.GetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered).Status).To(
+				Equal(corev1.ConditionUnknown))
+			Expect(persisted.Status.Sinks).To(HaveLen(1))
+			Expect(persisted.Status.Sinks[0].Pending).To(BeTrue())
+
+			finishCopyJob(batchv1.JobComplete, "")
+			_, persisted = reconcile()
+			Expect(persisted.Status.IsConditionTrue(perfv1alpha1.BenchmarkConditionResultsDelivered)).To(BeTrue())
+			Expect(persisted.Status.Sinks[0].Pending).To(BeFalse())
+			Expect(persisted.Status.Sinks[0].Location).To(Equal("pvc://results/kubestone/fio-sample/"))
+		})
+
+		It("should report the failed delivery when the copy job has failed", func() {
+			start()
+			finishJob(batchv1.JobComplete, "")
+			reconcile()
+
+			finishCopyJob(batchv1.JobFailed, "BackoffLimitExceeded")
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkSucceeded))
+			Expect(persisted.Status.GetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered).Status).To(
+				Equal(corev1.ConditionFalse))
+			Expect(persisted.Status.Sinks[0].Pending).To(BeFalse())
+			Expect(persisted.Status.Sinks[0].Error).To(ContainSubstring("BackoffLimitExceeded"))
+		})
+	})
+
+	Context("with a finished CR", func() {
+		It("should not create the objects of the benchmark again", func() {
+			cr.Status.MarkFailed("ValidationFailed", "CR validation failed")
+			cr.Status.ObservedGeneration = 1
+			_, persisted := reconcile()
+			Expect(persisted.Status.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+			Expect(getJob()).To(BeNil())
+		})
+	})
+
+	It("should report the errors of the benchmark", func() {
+		start()
+		_, err := engine.Reconcile(ctrl.Request{NamespacedName: name}, failingBenchmark{})
+		Expect(err).To(MatchError("no steps"))
+	})
+})
+
+// conflictingClient fails the first status update which records the
+// deliveries to the sinks with a conflict and counts the ConfigMaps
+// created for the results
+type conflictingClient struct {
+	client.Client
+	conflicted bool
+	deliveries int
+}
+
+func (c *conflictingClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	if configMap, ok := obj.(*corev1.ConfigMap); ok && configMap.Name == "fio-sample-results" {
+		c.deliveries++
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *conflictingClient) Status() client.StatusWriter {
+	return &conflictingStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type conflictingStatusWriter struct {
+	client.StatusWriter
+	client *conflictingClient
+}
+
+func (w *conflictingStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	if cr, ok := obj.(Object); ok && len(cr.GetBenchmarkStatus().Sinks) > 0 && !w.client.conflicted {
+		w.client.conflicted = true
+		return apierrors.NewConflict(schema.GroupResource{Resource: "fios"}, cr.GetName(),
+			errors.New("the object has been modified"))
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+// referringBenchmark refers to a Secret which does not exist
+type referringBenchmark struct {
+	jobBenchmark
+}
+
+func (referringBenchmark) ValidateReferences(cr Object) error {
+	return errors.New(`secrets "credentials" not found`)
+}
+
+// failingBenchmark is unable to build its steps
+type failingBenchmark struct {
+	fioBenchmark
+}
+
+func (failingBenchmark) Steps(cr Object) ([]Step, error) {
+	return nil, errors.New("no steps")
+}
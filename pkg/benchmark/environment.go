@@ -0,0 +1,176 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// The well-known labels of the nodes, the beta ones are used by older clusters
+var (
+	instanceTypeLabels = []string{"node.kubernetes.io/instance-type", "beta.kubernetes.io/instance-type"}
+	regionLabels       = []string{"topology.kubernetes.io/region", "failure-domain.beta.kubernetes.io/region"}
+	zoneLabels         = []string{"topology.kubernetes.io/zone", "failure-domain.beta.kubernetes.io/zone"}
+)
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get
+
+// fingerprint describes the nodes the pods of the benchmark have run on
+// and the PersistentVolumeClaims generated for the benchmark. It has to be
+// called before the pods are deleted. Failures are reported as events only.
+func (e *Engine) fingerprint(ctx context.Context, cr Object) *perfv1alpha1.BenchmarkEnvironment {
+	environment := &perfv1alpha1.BenchmarkEnvironment{}
+
+	pods, err := e.K8S.GetControlledPods(cr)
+	if err != nil {
+		e.reportFingerprintFailure(cr, err)
+		return nil
+	}
+	podsByNode := map[string][]string{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod.Name)
+		}
+	}
+	var nodeNames []string
+	for name := range podsByNode {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, name := range nodeNames {
+		node, err := e.K8S.Clientset.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+		if err != nil {
+			e.reportFingerprintFailure(cr, err)
+			continue
+		}
+		sort.Strings(podsByNode[name])
+		environment.Nodes = append(environment.Nodes, nodeFingerprint(node, podsByNode[name]))
+	}
+
+	objects, err := e.K8S.ListControlledObjects(cr)
+	if err != nil {
+		e.reportFingerprintFailure(cr, err)
+		return environment
+	}
+	for _, object := range objects {
+		claim, ok := object.(*corev1.PersistentVolumeClaim)
+		if !ok {
+			continue
+		}
+		var volume *corev1.PersistentVolume
+		if claim.Spec.VolumeName != "" {
+			volume, err = e.K8S.Clientset.CoreV1().PersistentVolumes().Get(
+				claim.Spec.VolumeName, metav1.GetOptions{})
+			if err != nil {
+				e.reportFingerprintFailure(cr, err)
+			}
+		}
+		var storageClass *storagev1.StorageClass
+		if name := storageClassOf(claim); name != "" {
+			storageClass, err = e.K8S.Clientset.StorageV1().StorageClasses().Get(name, metav1.GetOptions{})
+			if err != nil {
+				e.reportFingerprintFailure(cr, err)
+			}
+		}
+		environment.Volumes = append(environment.Volumes, volumeFingerprint(claim, volume, storageClass))
+	}
+	return environment
+}
+
+// reportFingerprintFailure logs the error and records it as an event
+func (e *Engine) reportFingerprintFailure(cr Object, err error) {
+	e.Log.Error(err, "Unable to describe the environment of the benchmark")
+	_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.FingerprintFailed,
+		"Failed to describe the environment of the benchmark: %v", err)
+}
+
+// nodeFingerprint describes the node the given pods have run on
+func nodeFingerprint(node *corev1.Node, pods []string) perfv1alpha1.NodeFingerprint {
+	info := node.Status.NodeInfo
+	fingerprint := perfv1alpha1.NodeFingerprint{
+		Name:                    node.Name,
+		Pods:                    pods,
+		InstanceType:            labelOf(node.Labels, instanceTypeLabels),
+		Region:                  labelOf(node.Labels, regionLabels),
+		Zone:                    labelOf(node.Labels, zoneLabels),
+		Architecture:            info.Architecture,
+		OSImage:                 info.OSImage,
+		KernelVersion:           info.KernelVersion,
+		ContainerRuntimeVersion: info.ContainerRuntimeVersion,
+		KubeletVersion:          info.KubeletVersion,
+	}
+	for _, resource := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if quantity, ok := node.Status.Capacity[resource]; ok {
+			if fingerprint.Capacity == nil {
+				fingerprint.Capacity = corev1.ResourceList{}
+			}
+			fingerprint.Capacity[resource] = quantity
+		}
+	}
+	return fingerprint
+}
+
+// volumeFingerprint describes the claim with its volume and storage
+// class, which are nil if they are unknown
+func volumeFingerprint(claim *corev1.PersistentVolumeClaim, volume *corev1.PersistentVolume,
+	storageClass *storagev1.StorageClass) perfv1alpha1.VolumeFingerprint {
+	fingerprint := perfv1alpha1.VolumeFingerprint{
+		ClaimName:        claim.Name,
+		VolumeName:       claim.Spec.VolumeName,
+		StorageClassName: storageClassOf(claim),
+	}
+	if storageClass != nil {
+		fingerprint.Provisioner = storageClass.Provisioner
+		fingerprint.Parameters = storageClass.Parameters
+	}
+	if volume != nil {
+		if volume.Spec.CSI != nil {
+			fingerprint.Driver = volume.Spec.CSI.Driver
+		}
+		fingerprint.Capacity = volume.Spec.Capacity
+		fingerprint.AccessModes = volume.Spec.AccessModes
+	}
+	return fingerprint
+}
+
+// storageClassOf returns the name of the StorageClass of the claim
+func storageClassOf(claim *corev1.PersistentVolumeClaim) string {
+	if claim.Spec.StorageClassName != nil {
+		return *claim.Spec.StorageClassName
+	}
+	return claim.Annotations[corev1.BetaStorageClassAnnotation]
+}
+
+// labelOf returns the value of the first present label of keys
+func labelOf(labels map[string]string, keys []string) string {
+	for _, key := range keys {
+		if value, ok := labels[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
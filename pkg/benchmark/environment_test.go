@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Environment", func() {
+	It("should describe the node", func() {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Labels: map[string]string{
+					"beta.kubernetes.io/instance-type": "m5.large",
+					"topology.kubernetes.io/zone":      "eu-west-1a",
+				},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				NodeInfo: corev1.NodeSystemInfo{
+					KernelVersion:           "4.14.146",
+					ContainerRuntimeVersion: "docker://18.6.1",
+					KubeletVersion:          "v1.14.7",
+				},
+			},
+		}
+
+		fingerprint := nodeFingerprint(node, []string{"fio-sample-abcde"})
+		Expect(fingerprint.Name).To(Equal("node-1"))
+		Expect(fingerprint.Pods).To(Equal([]string{"fio-sample-abcde"}))
+		Expect(fingerprint.InstanceType).To(Equal("m5.large"))
+		Expect(fingerprint.Zone).To(Equal("eu-west-1a"))
+		Expect(fingerprint.Region).To(BeEmpty())
+		Expect(fingerprint.KernelVersion).To(Equal("4.14.146"))
+		Expect(fingerprint.ContainerRuntimeVersion).To(Equal("docker://18.6.1"))
+		Expect(fingerprint.KubeletVersion).To(Equal("v1.14.7"))
+		Expect(fingerprint.Capacity).To(HaveLen(2))
+		Expect(fingerprint.Capacity).To(HaveKey(corev1.ResourceMemory))
+	})
+
+	It("should describe the generated volume", func() {
+		className := "fast"
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "fio-sample"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &className,
+				VolumeName:       "pvc-1234",
+			},
+		}
+		volume := &corev1.PersistentVolume{Spec: corev1.PersistentVolumeSpec{
+			Capacity:    corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com"},
+			},
+		}}
+		storageClass := &storagev1.StorageClass{
+			Provisioner: "ebs.csi.aws.com",
+			Parameters:  map[string]string{"type": "io1"},
+		}
+
+		fingerprint := volumeFingerprint(claim, volume, storageClass)
+		Expect(fingerprint.ClaimName).To(Equal("fio-sample"))
+		Expect(fingerprint.VolumeName).To(Equal("pvc-1234"))
+		Expect(fingerprint.StorageClassName).To(Equal("fast"))
+		Expect(fingerprint.Provisioner).To(Equal("ebs.csi.aws.com"))
+		Expect(fingerprint.Parameters).To(HaveKeyWithValue("type", "io1"))
+		Expect(fingerprint.Driver).To(Equal("ebs.csi.aws.com"))
+		Expect(fingerprint.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+	})
+
+	It("should describe the unbound volume", func() {
+		claim := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:        "fio-sample",
+			Annotations: map[string]string{corev1.BetaStorageClassAnnotation: "standard"},
+		}}
+		fingerprint := volumeFingerprint(claim, nil, nil)
+		Expect(fingerprint.StorageClassName).To(Equal("standard"))
+		Expect(fingerprint.Capacity).To(BeEmpty())
+	})
+})
@@ -0,0 +1,183 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/results"
+)
+
+// FanOutNodeAnnotation records the node of the per node jobs of the
+// fanned out benchmarks
+const FanOutNodeAnnotation = "kubestone.xridge.io/node"
+
+// FanOutObject is implemented by the benchmark CRs of the single pod
+// benchmarks, which can run on every node matching a selector
+type FanOutObject interface {
+	GetFanOut() *perfv1alpha1.FanOutSpec
+}
+
+// fanOutOf returns the fan-out configuration of the benchmark, or nil
+// if the benchmark is not fanned out
+func fanOutOf(cr Object) *perfv1alpha1.FanOutSpec {
+	if object, ok := cr.(FanOutObject); ok {
+		return object.GetFanOut()
+	}
+	return nil
+}
+
+// validateFanOut checks the fan-out configuration of the benchmark
+func validateFanOut(cr Object) error {
+	fanOut := fanOutOf(cr)
+	if fanOut == nil || fanOut.OutlierTolerance == "" {
+		return nil
+	}
+	if _, err := strconv.ParseFloat(fanOut.OutlierTolerance, 64); err != nil {
+		return fmt.Errorf("invalid outlier tolerance %q: %v", fanOut.OutlierTolerance, err)
+	}
+	return nil
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=list
+
+// fanOutNodes returns the names of the ready and schedulable nodes
+// matching the selector of the fan-out configuration
+func (e *Engine) fanOutNodes(fanOut *perfv1alpha1.FanOutSpec) ([]string, error) {
+	nodes, err := e.K8S.Clientset.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(fanOut.NodeSelector).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable && isNodeReady(&node) {
+			names = append(names, node.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// isNodeReady returns true if the node has the Ready condition
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// newFanOutStatus returns the status of the benchmark fanned out to the nodes
+func newFanOutStatus(nodes []string) *perfv1alpha1.FanOutStatus {
+	status := &perfv1alpha1.FanOutStatus{}
+	for _, node := range nodes {
+		status.Nodes = append(status.Nodes, perfv1alpha1.FanOutNode{Node: node})
+	}
+	return status
+}
+
+// newNodeJob returns the copy of the job which runs on the given node
+func newNodeJob(job *batchv1.Job, node string, index int) *batchv1.Job {
+	nodeJob := job.DeepCopy()
+	nodeJob.Name = fmt.Sprintf("%s-%d", job.Name, index)
+	if nodeJob.Annotations == nil {
+		nodeJob.Annotations = map[string]string{}
+	}
+	nodeJob.Annotations[FanOutNodeAnnotation] = node
+	nodeJob.Spec.Template.Spec.NodeName = node
+	return nodeJob
+}
+
+// createFanOutJobs creates a copy of the job for each node of the fanned
+// out benchmark. In Serial mode a job is only created once the job on
+// the previous node has finished.
+func (e *Engine) createFanOutJobs(ctx context.Context, cr Object, job *batchv1.Job) ([]*batchv1.Job, error) {
+	status := cr.GetBenchmarkStatus()
+	serial := fanOutOf(cr).Mode == perfv1alpha1.FanOutSerial
+
+	var jobs []*batchv1.Job
+	for i, node := range status.FanOut.Nodes {
+		if serial && i > 0 {
+			outcomes, err := e.jobOutcomes(jobs[i-1:])
+			if err != nil {
+				return nil, err
+			}
+			if !outcomes[0].Finished() {
+				break
+			}
+		}
+		nodeJob := newNodeJob(job, node.Node, i)
+		if err := e.K8S.CreateWithReference(ctx, nodeJob, cr); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, nodeJob)
+	}
+	return jobs, nil
+}
+
+// recordFanOut records the jobs of the fanned out benchmark with their
+// outcome in the status
+func recordFanOut(status *perfv1alpha1.BenchmarkStatus, jobs []*batchv1.Job, outcomes []k8s.JobOutcome) {
+	for i, job := range jobs {
+		node := job.Annotations[FanOutNodeAnnotation]
+		for j := range status.FanOut.Nodes {
+			if status.FanOut.Nodes[j].Node != node {
+				continue
+			}
+			status.FanOut.Nodes[j].Job = job.Name
+			status.FanOut.Nodes[j].Message = outcomes[i].Message
+			switch outcomes[i].Phase {
+			case k8s.JobSucceeded:
+				status.FanOut.Nodes[j].Phase = perfv1alpha1.BenchmarkSucceeded
+			case k8s.JobFailed:
+				status.FanOut.Nodes[j].Phase = perfv1alpha1.BenchmarkFailed
+			}
+		}
+	}
+}
+
+// findOutliers records the per node metrics deviating from the median of
+// the nodes in the status
+func findOutliers(cr Object) error {
+	status := cr.GetBenchmarkStatus()
+	if status.Results == nil {
+		return nil
+	}
+	tolerance := fanOutOf(cr).OutlierTolerance
+	if tolerance == "" {
+		tolerance = perfv1alpha1.DefaultOutlierTolerance
+	}
+	percent, err := strconv.ParseFloat(tolerance, 64)
+	if err != nil {
+		return err
+	}
+	status.FanOut.Outliers, err = results.FindOutliers(status.Results.Metrics, percent)
+	return err
+}
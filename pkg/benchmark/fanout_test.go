@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+var _ = Describe("FanOut", func() {
+	It("should only fan out the single pod benchmarks", func() {
+		fio := &perfv1alpha1.Fio{}
+		Expect(fanOutOf(fio)).To(BeNil())
+		fio.Spec.FanOut = &perfv1alpha1.FanOutSpec{}
+		Expect(fanOutOf(fio)).NotTo(BeNil())
+		Expect(fanOutOf(&perfv1alpha1.Iperf3{})).To(BeNil())
+	})
+
+	It("should validate the outlier tolerance", func() {
+		cr := &perfv1alpha1.Sysbench{}
+		cr.Spec.FanOut = &perfv1alpha1.FanOutSpec{OutlierTolerance: "15"}
+		Expect(validateFanOut(cr)).To(Succeed())
+		cr.Spec.FanOut.OutlierTolerance = "15%"
+		Expect(validateFanOut(cr)).NotTo(Succeed())
+	})
+
+	It("should pin the copies of the job to the nodes", func() {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fio-sample"}}
+		nodeJob := newNodeJob(job, "node-2", 1)
+		Expect(nodeJob.Name).To(Equal("fio-sample-1"))
+		Expect(nodeJob.Annotations).To(HaveKeyWithValue(FanOutNodeAnnotation, "node-2"))
+		Expect(nodeJob.Spec.Template.Spec.NodeName).To(Equal("node-2"))
+		Expect(job.Spec.Template.Spec.NodeName).To(BeEmpty())
+	})
+
+	It("should select the ready nodes only", func() {
+		node := &corev1.Node{}
+		Expect(isNodeReady(node)).To(BeFalse())
+		node.Status.Conditions = []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}
+		Expect(isNodeReady(node)).To(BeTrue())
+	})
+
+	It("should record the outcome of the per node jobs", func() {
+		status := &perfv1alpha1.BenchmarkStatus{FanOut: newFanOutStatus([]string{"node-1", "node-2"})}
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fio-sample"}}
+		jobs := []*batchv1.Job{newNodeJob(job, "node-1", 0), newNodeJob(job, "node-2", 1)}
+		recordFanOut(status, jobs, []k8s.JobOutcome{
+			{Phase: k8s.JobSucceeded},
+			{Phase: k8s.JobFailed, Message: "BackoffLimitExceeded"},
+		})
+
+		Expect(status.FanOut.Nodes[0]).To(Equal(perfv1alpha1.FanOutNode{
+			Node: "node-1", Job: "fio-sample-0", Phase: perfv1alpha1.BenchmarkSucceeded,
+		}))
+		Expect(status.FanOut.Nodes[1].Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+		Expect(status.FanOut.Nodes[1].Message).To(Equal("BackoffLimitExceeded"))
+	})
+})
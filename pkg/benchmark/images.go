@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ImageCatalog holds the operator-wide image defaults of the benchmarks.
+// The fields of the ImageSpecs left empty in the CRs are filled from the
+// catalog, so image versions and registries can be managed centrally.
+type ImageCatalog struct {
+	// Registry replaces the registry of the default images
+	// (e.g. to pull them from an internal mirror)
+	Registry string `json:"registry,omitempty"`
+
+	// PullPolicy is the pull policy used when neither the CR
+	// nor the image of the benchmark in the catalog specify it
+	PullPolicy perfv1alpha1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// PullSecret is the pull secret used when neither the CR
+	// nor the image of the benchmark in the catalog specify it
+	PullSecret string `json:"pullSecret,omitempty"`
+
+	// PullSecrets are the pull secrets added to every image
+	// along with the ones specified in the CR
+	PullSecrets []string `json:"pullSecrets,omitempty"`
+
+	// Images are the default images keyed by the name of the benchmark
+	// (e.g. fio, iperf3, s3bench)
+	Images map[string]perfv1alpha1.ImageSpec `json:"images,omitempty"`
+}
+
+// DefaultImageCatalog returns the catalog of the images the benchmarks
+// are tested with
+func DefaultImageCatalog() *ImageCatalog {
+	return &ImageCatalog{
+		Images: map[string]perfv1alpha1.ImageSpec{
+			"drill":      {Name: "xridge/drill:0.5.0"},
+			"esrally":    {Name: "diamantisolutions/esrally:kubestone", PullPolicy: "Always"},
+			"fio":        {Name: "xridge/fio:3.13"},
+			"ioping":     {Name: "xridge/ioping:1.1"},
+			"iperf3":     {Name: "xridge/iperf3:3.7.0"},
+			"jmeter":     {Name: "justb4/jmeter:5.3"},
+			"kafkabench": {Name: "confluentinc/cp-kafka:5.2.1"},
+			"ocplogtest": {Name: "quay.io/mffiedler/ocp-logtest:latest"},
+			"pgbench":    {Name: "xridge/pgbench:latest"},
+			"pvcsink":    {Name: "alpine:3"},
+			"qperf":      {Name: "xridge/qperf:0.4.11-r0"},
+			"s3bench":    {Name: "minio/warp:v0.3.5", PullPolicy: "IfNotPresent"},
+			"sysbench":   {Name: "xridge/sysbench:1.0.17-1"},
+			"ycsbbench":  {Name: "diamantisolutions/ycsb:latest"},
+		},
+	}
+}
+
+// LoadImageCatalog reads the YAML (or JSON) catalog file at path and
+// merges it with the DefaultImageCatalog: the images of the file
+// override the built-in ones of the same benchmark.
+func LoadImageCatalog(path string) (*ImageCatalog, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded ImageCatalog
+	if err := yaml.UnmarshalStrict(data, &loaded); err != nil {
+		return nil, err
+	}
+
+	catalog := DefaultImageCatalog()
+	catalog.Registry = loaded.Registry
+	catalog.PullPolicy = loaded.PullPolicy
+	catalog.PullSecret = loaded.PullSecret
+	catalog.PullSecrets = loaded.PullSecrets
+	for name, image := range loaded.Images {
+		catalog.Images[name] = image
+	}
+	return catalog, nil
+}
+
+// Default fills the empty fields of image with the defaults of the given
+// benchmark. The pull policy and pull secrets of the benchmark's default
+// image are only used along with its name, the catalog-wide ones are
+// applied to user provided images as well. The catalog-wide PullSecrets
+// are added to the pull secrets of the image, duplicates are left out.
+// A nil catalog is handled as the DefaultImageCatalog.
+func (c *ImageCatalog) Default(benchmark string, image *perfv1alpha1.ImageSpec) {
+	if c == nil {
+		c = DefaultImageCatalog()
+	}
+
+	if defaults, ok := c.Images[benchmark]; ok && image.Name == "" {
+		image.Name = c.withRegistry(defaults.Name)
+		if image.PullPolicy == "" {
+			image.PullPolicy = defaults.PullPolicy
+		}
+		if image.PullSecret == "" {
+			image.PullSecret = defaults.PullSecret
+		}
+		image.PullSecrets = appendMissing(image.PullSecrets, defaults.PullSecrets...)
+	}
+
+	if image.PullPolicy == "" {
+		image.PullPolicy = c.PullPolicy
+	}
+	if image.PullSecret == "" {
+		image.PullSecret = c.PullSecret
+	}
+	image.PullSecrets = appendMissing(image.PullSecrets, c.PullSecrets...)
+}
+
+// appendMissing appends the values to the slice which are not in it yet
+func appendMissing(slice []string, values ...string) []string {
+	for _, value := range values {
+		if !contains(slice, value) {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
+
+// withRegistry replaces the registry of the image name with the
+// registry of the catalog
+func (c *ImageCatalog) withRegistry(name string) string {
+	if c.Registry == "" {
+		return name
+	}
+
+	// The first component is a registry if it looks like a host name,
+	// otherwise the image is on Docker Hub
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		name = parts[1]
+	}
+	return strings.TrimSuffix(c.Registry, "/") + "/" + name
+}
+
+// imagesOf returns the names of the images in the spec of the CR,
+// without duplicates
+func imagesOf(cr Object) []string {
+	var images []string
+	collectImages(reflect.Indirect(reflect.ValueOf(cr)).FieldByName("Spec"), &images)
+	return images
+}
+
+// collectImages appends the names of the images found in the given value
+func collectImages(value reflect.Value, images *[]string) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			collectImages(value.Elem(), images)
+		}
+	case reflect.Struct:
+		if image, ok := value.Interface().(perfv1alpha1.ImageSpec); ok {
+			if image.Name != "" && !contains(*images, image.Name) {
+				*images = append(*images, image.Name)
+			}
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath == "" {
+				collectImages(value.Field(i), images)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			collectImages(value.Index(i), images)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("image catalog", func() {
+	var catalog *ImageCatalog
+
+	BeforeEach(func() {
+		catalog = DefaultImageCatalog()
+	})
+
+	Context("with an empty image", func() {
+		It("should use the default image of the benchmark", func() {
+			image := perfv1alpha1.ImageSpec{}
+			catalog.Default("s3bench", &image)
+			Expect(image).To(Equal(perfv1alpha1.ImageSpec{
+				Name:       "minio/warp:v0.3.5",
+				PullPolicy: "IfNotPresent",
+			}))
+		})
+
+		It("should replace the registry of the default image", func() {
+			catalog.Registry = "registry.example.com/mirror/"
+			catalog.Images["ocplogtest"] = perfv1alpha1.ImageSpec{Name: "quay.io/mffiedler/ocp-logtest:latest"}
+
+			image := perfv1alpha1.ImageSpec{}
+			catalog.Default("fio", &image)
+			Expect(image.Name).To(Equal("registry.example.com/mirror/xridge/fio:3.13"))
+
+			image = perfv1alpha1.ImageSpec{}
+			catalog.Default("ocplogtest", &image)
+			Expect(image.Name).To(Equal("registry.example.com/mirror/mffiedler/ocp-logtest:latest"))
+		})
+	})
+
+	Context("with a user provided image", func() {
+		It("should keep the image and only apply the catalog-wide defaults", func() {
+			catalog.PullSecret = "registry-credentials"
+			catalog.Registry = "registry.example.com"
+
+			image := perfv1alpha1.ImageSpec{Name: "minio/warp:latest"}
+			catalog.Default("s3bench", &image)
+			Expect(image).To(Equal(perfv1alpha1.ImageSpec{
+				Name:       "minio/warp:latest",
+				PullSecret: "registry-credentials",
+			}))
+		})
+
+		It("should add the catalog-wide pull secrets once", func() {
+			catalog.PullSecrets = []string{"mirror-credentials", "proxy-credentials"}
+
+			image := perfv1alpha1.ImageSpec{
+				Name:        "minio/warp:latest",
+				PullSecrets: []string{"proxy-credentials"},
+			}
+			catalog.Default("s3bench", &image)
+			catalog.Default("s3bench", &image)
+			Expect(image.PullSecrets).To(Equal(
+				[]string{"proxy-credentials", "mirror-credentials"}))
+		})
+	})
+
+	Context("without a catalog", func() {
+		It("should use the built-in defaults", func() {
+			var nilCatalog *ImageCatalog
+			image := perfv1alpha1.ImageSpec{}
+			nilCatalog.Default("esrally", &image)
+			Expect(image.Name).To(Equal("diamantisolutions/esrally:kubestone"))
+		})
+	})
+
+	Context("loaded from a file", func() {
+		var path string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "images")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.WriteString(`
+pullPolicy: IfNotPresent
+images:
+  fio:
+    name: registry.example.com/fio:3.16
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			path = file.Name()
+		})
+
+		AfterEach(func() {
+			os.Remove(path)
+		})
+
+		It("should override the built-in defaults", func() {
+			loaded, err := LoadImageCatalog(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			image := perfv1alpha1.ImageSpec{}
+			loaded.Default("fio", &image)
+			Expect(image).To(Equal(perfv1alpha1.ImageSpec{
+				Name:       "registry.example.com/fio:3.16",
+				PullPolicy: "IfNotPresent",
+			}))
+
+			image = perfv1alpha1.ImageSpec{}
+			loaded.Default("drill", &image)
+			Expect(image.Name).To(Equal("xridge/drill:0.5.0"))
+		})
+
+		It("should reject unknown fields", func() {
+			Expect(ioutil.WriteFile(path, []byte("image: fio"), 0600)).To(Succeed())
+			_, err := LoadImageCatalog(path)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("imagesOf", func() {
+		It("should return the images of the spec once", func() {
+			cr := &perfv1alpha1.JMeter{Spec: perfv1alpha1.JMeterSpec{
+				Controller: &perfv1alpha1.JMeterController{Image: perfv1alpha1.ImageSpec{Name: "justb4/jmeter:5.3"}},
+				Workers:    &perfv1alpha1.JMeterWorkers{Image: perfv1alpha1.ImageSpec{Name: "justb4/jmeter:5.3"}},
+			}}
+			Expect(imagesOf(cr)).To(Equal([]string{"justb4/jmeter:5.3"}))
+		})
+
+		It("should leave out the unset images", func() {
+			Expect(imagesOf(&perfv1alpha1.Fio{})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/xridge/kubestone/pkg/results"
+)
+
+// Results exports the results of the finished benchmarks as Prometheus
+// gauges. It is registered in the metrics registry of controller-runtime,
+// so the gauges are served on the metrics endpoint of the manager.
+var Results = results.NewExporter()
+
+func init() {
+	metrics.Registry.MustRegister(Results)
+}
+
+// exportResults updates the gauges of the finished benchmark CR
+func (e *Engine) exportResults(cr Object) {
+	gvk, err := apiutil.GVKForObject(cr, e.K8S.Scheme)
+	if err != nil {
+		e.Log.Error(err, "Unable to export the benchmark results")
+		return
+	}
+	Results.SetResults(gvk.Kind, types.NamespacedName{
+		Namespace: cr.GetNamespace(),
+		Name:      cr.GetName(),
+	}, cr.GetBenchmarkStatus())
+}
+
+// unexportResults removes the gauges of the deleted benchmark CR
+func (e *Engine) unexportResults(b Benchmark, name types.NamespacedName) {
+	gvk, err := apiutil.GVKForObject(b.NewObject(), e.K8S.Scheme)
+	if err != nil {
+		return
+	}
+	Results.DeleteResults(gvk.Kind, name)
+}
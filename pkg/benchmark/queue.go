@@ -0,0 +1,331 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// QueuePollInterval is the interval at which the queued benchmarks
+// check whether they can be admitted
+const QueuePollInterval = 10 * time.Second
+
+// admissionTTL is the time the admitted benchmarks are counted as running,
+// even if the cache does not reflect their new phase yet
+const admissionTTL = time.Minute
+
+// ConcurrencyLimits caps the number of benchmarks running at the same
+// time. Zero means no limit.
+type ConcurrencyLimits struct {
+	// Global is the maximum number of benchmarks running in the cluster
+	Global int
+	// PerNamespace is the maximum number of benchmarks running in a namespace
+	PerNamespace int
+	// PerNode is the maximum number of benchmarks running on a node
+	PerNode int
+}
+
+// Queue admits the benchmarks of all kinds according to the concurrency
+// limits and to their exclusivity. Benchmarks which cannot be admitted
+// wait in the Queued phase, in the order of their creation. The Queue is
+// shared by the controllers of the operator.
+type Queue struct {
+	Limits ConcurrencyLimits
+
+	mutex    sync.Mutex
+	admitted map[types.UID]time.Time
+}
+
+// NewQueue returns a Queue enforcing the given limits
+func NewQueue(limits ConcurrencyLimits) *Queue {
+	return &Queue{Limits: limits, admitted: map[types.UID]time.Time{}}
+}
+
+// slot describes where a benchmark runs
+type slot struct {
+	cr        Object
+	namespace string
+	// nodes are the nodes (or node pools) the benchmark is pinned to
+	nodes []string
+	// anyNode is true if some pods of the benchmark may run on any node
+	anyNode   bool
+	exclusive bool
+}
+
+// newSlot returns the slot of the benchmark. The nodes are derived from
+// the NodeName and the NodeSelector of the pods: a NodeSelector without
+// the hostname label refers to the pool of the matching nodes.
+func newSlot(cr Object) slot {
+	s := slot{cr: cr, namespace: cr.GetNamespace(), exclusive: cr.GetBenchmarkSpec().Exclusive}
+	for _, scheduling := range cr.GetPodScheduling() {
+		node := nodeOf(scheduling)
+		if node == "" {
+			s.anyNode = true
+		} else if !contains(s.nodes, node) {
+			s.nodes = append(s.nodes, node)
+		}
+	}
+	if len(s.nodes) == 0 {
+		s.anyNode = true
+	}
+	return s
+}
+
+// nodeOf returns the node or the node pool the pod is pinned to, or an
+// empty string if the pod may run on any node
+func nodeOf(scheduling perfv1alpha1.PodSchedulingSpec) string {
+	if scheduling.NodeName != "" {
+		return scheduling.NodeName
+	}
+	if hostname, ok := scheduling.NodeSelector["kubernetes.io/hostname"]; ok {
+		return hostname
+	}
+	if len(scheduling.NodeSelector) == 0 {
+		return ""
+	}
+	var labels []string
+	for key, value := range scheduling.NodeSelector {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ",")
+}
+
+// sharesNode returns true if the two benchmarks may run on the same node
+func (s slot) sharesNode(other slot) bool {
+	if s.anyNode || other.anyNode {
+		return true
+	}
+	for _, node := range s.nodes {
+		if contains(other.nodes, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// excludes returns true if the two benchmarks may not run at the same time
+// due to the exclusivity of either of them
+func (s slot) excludes(other slot) bool {
+	return (s.exclusive || other.exclusive) && s.sharesNode(other)
+}
+
+// holdsBack returns true if an earlier benchmark which does not fit
+// competes with the candidate for the same nodes
+func (l ConcurrencyLimits) holdsBack(held []slot, candidate slot) bool {
+	for _, s := range held {
+		if s.excludes(candidate) {
+			return true
+		}
+		if l.PerNode == 0 {
+			continue
+		}
+		for _, node := range s.nodes {
+			if contains(candidate.nodes, node) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fits returns true if the benchmark can run alongside the running ones
+func (l ConcurrencyLimits) fits(candidate slot, running []slot) bool {
+	if l.Global > 0 && len(running) >= l.Global {
+		return false
+	}
+	inNamespace := 0
+	onNode := map[string]int{}
+	for _, s := range running {
+		if candidate.excludes(s) {
+			return false
+		}
+		if s.namespace == candidate.namespace {
+			inNamespace++
+		}
+		for _, node := range s.nodes {
+			onNode[node]++
+		}
+	}
+	if l.PerNamespace > 0 && inNamespace >= l.PerNamespace {
+		return false
+	}
+	for _, node := range candidate.nodes {
+		if l.PerNode > 0 && onNode[node] >= l.PerNode {
+			return false
+		}
+	}
+	return true
+}
+
+// Admit returns zero if the benchmark can start, or its position in the
+// queue otherwise. The queued benchmarks are admitted in the order of their
+// creation, as long as they fit. A benchmark which does not fit holds back
+// the later benchmarks competing for its nodes, so exclusive benchmarks
+// are not starved.
+func (q *Queue) Admit(ctx context.Context, access *k8s.Access, cr Object) (int32, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	crs, err := listBenchmarks(ctx, access)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for uid, admitted := range q.admitted {
+		if now.Sub(admitted) > admissionTTL {
+			delete(q.admitted, uid)
+		}
+	}
+
+	var running, queued []slot
+	queued = append(queued, newSlot(cr))
+	for _, other := range crs {
+		if other.GetUID() == cr.GetUID() {
+			continue
+		}
+		status := other.GetBenchmarkStatus()
+		switch {
+		case status.Finished():
+		case status.Phase == perfv1alpha1.BenchmarkProvisioning ||
+			status.Phase == perfv1alpha1.BenchmarkRunning:
+			running = append(running, newSlot(other))
+		case !q.admitted[other.GetUID()].IsZero():
+			running = append(running, newSlot(other))
+		case status.Phase == perfv1alpha1.BenchmarkQueued:
+			queued = append(queued, newSlot(other))
+		}
+	}
+
+	position := q.simulate(running, queued, cr)
+	if position == 0 {
+		q.admitted[cr.GetUID()] = now
+	}
+	return position, nil
+}
+
+// simulate admits the queued benchmarks in order and returns the queue
+// position of the given benchmark, zero if it has been admitted
+func (q *Queue) simulate(running, queued []slot, cr Object) int32 {
+	sort.SliceStable(queued, func(i, j int) bool {
+		a, b := queued[i].cr, queued[j].cr
+		createdA, createdB := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+		if !createdA.Equal(&createdB) {
+			return createdA.Before(&createdB)
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+
+	var held []slot
+	for _, candidate := range queued {
+		admitted := q.Limits.fits(candidate, running) && !q.Limits.holdsBack(held, candidate)
+		if admitted {
+			running = append(running, candidate)
+		} else {
+			held = append(held, candidate)
+		}
+		if candidate.cr.GetUID() == cr.GetUID() {
+			if admitted {
+				return 0
+			}
+			return int32(len(held))
+		}
+	}
+	return 0
+}
+
+// listBenchmarks returns the benchmark CRs of all kinds in the cluster
+func listBenchmarks(ctx context.Context, access *k8s.Access) ([]Object, error) {
+	var crs []Object
+	for _, kind := range TemplateKinds {
+		list, err := access.Scheme.New(perfv1alpha1.GroupVersion.WithKind(kind + "List"))
+		if err != nil {
+			return nil, err
+		}
+		if err := access.Client.List(ctx, list); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if cr, ok := item.(Object); ok {
+				crs = append(crs, cr)
+			}
+		}
+	}
+	return crs, nil
+}
+
+// contains returns true if value is among values
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// admit moves the benchmark to the Queued phase and returns true if it
+// cannot start yet. Queued benchmarks are polled, as the benchmarks they
+// wait for can be of any kind.
+func (e *Engine) admit(ctx context.Context, cr Object) (ctrl.Result, bool, error) {
+	if e.Queue == nil {
+		return ctrl.Result{}, false, nil
+	}
+	position, err := e.Queue.Admit(ctx, e.K8S, cr)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	status := cr.GetBenchmarkStatus()
+	if position == 0 {
+		status.QueuePosition = 0
+		return ctrl.Result{}, false, nil
+	}
+
+	result := ctrl.Result{RequeueAfter: QueuePollInterval}
+	if status.Phase == perfv1alpha1.BenchmarkQueued && status.QueuePosition == position {
+		return result, true, nil
+	}
+	if status.Phase != perfv1alpha1.BenchmarkQueued {
+		_ = e.K8S.RecordEventf(cr, corev1.EventTypeNormal, k8s.Queued,
+			"Waiting for other benchmarks to finish, position in queue: %d", position)
+	}
+	status.SetPhase(perfv1alpha1.BenchmarkQueued,
+		fmt.Sprintf("Waiting for other benchmarks to finish, position in queue: %d", position))
+	status.QueuePosition = position
+	return result, true, e.K8S.Client.Status().Update(ctx, cr)
+}
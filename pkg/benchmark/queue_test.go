@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// newQueuedFio returns a Fio CR created at the given minute, pinned to
+// the given node unless it is empty
+func newQueuedFio(name, namespace, node string, minute int, exclusive bool) *perfv1alpha1.Fio {
+	cr := &perfv1alpha1.Fio{ObjectMeta: metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         namespace,
+		UID:               types.UID(namespace + "/" + name),
+		CreationTimestamp: metav1.NewTime(time.Date(2019, 9, 8, 5, minute, 0, 0, time.UTC)),
+	}}
+	cr.Spec.PodConfig.PodScheduling.NodeName = node
+	cr.Spec.Exclusive = exclusive
+	return cr
+}
+
+var _ = Describe("Queue", func() {
+	It("should derive the node from the scheduling of the pods", func() {
+		Expect(nodeOf(perfv1alpha1.PodSchedulingSpec{})).To(BeEmpty())
+		Expect(nodeOf(perfv1alpha1.PodSchedulingSpec{NodeName: "node-1"})).To(Equal("node-1"))
+		Expect(nodeOf(perfv1alpha1.PodSchedulingSpec{
+			NodeSelector: map[string]string{"kubernetes.io/hostname": "node-2"},
+		})).To(Equal("node-2"))
+		Expect(nodeOf(perfv1alpha1.PodSchedulingSpec{
+			NodeSelector: map[string]string{"zone": "a", "disk": "ssd"},
+		})).To(Equal("disk=ssd,zone=a"))
+	})
+
+	It("should enforce the global and namespace limits", func() {
+		running := []slot{newSlot(newQueuedFio("a", "team-a", "", 0, false))}
+		candidate := newSlot(newQueuedFio("b", "team-a", "", 1, false))
+		other := newSlot(newQueuedFio("c", "team-b", "", 1, false))
+
+		Expect(ConcurrencyLimits{}.fits(candidate, running)).To(BeTrue())
+		Expect(ConcurrencyLimits{Global: 1}.fits(candidate, running)).To(BeFalse())
+		Expect(ConcurrencyLimits{PerNamespace: 1}.fits(candidate, running)).To(BeFalse())
+		Expect(ConcurrencyLimits{PerNamespace: 1}.fits(other, running)).To(BeTrue())
+	})
+
+	It("should enforce the node limit", func() {
+		running := []slot{newSlot(newQueuedFio("a", "team-a", "node-1", 0, false))}
+		limits := ConcurrencyLimits{PerNode: 1}
+		Expect(limits.fits(newSlot(newQueuedFio("b", "team-b", "node-1", 1, false)), running)).To(BeFalse())
+		Expect(limits.fits(newSlot(newQueuedFio("c", "team-b", "node-2", 1, false)), running)).To(BeTrue())
+	})
+
+	It("should give the nodes of exclusive benchmarks to themselves", func() {
+		running := []slot{newSlot(newQueuedFio("a", "team-a", "node-1", 0, true))}
+		limits := ConcurrencyLimits{}
+		Expect(limits.fits(newSlot(newQueuedFio("b", "team-b", "node-1", 1, false)), running)).To(BeFalse())
+		Expect(limits.fits(newSlot(newQueuedFio("c", "team-b", "node-2", 1, false)), running)).To(BeTrue())
+		// Benchmarks which are not pinned may run on the node of the exclusive one
+		Expect(limits.fits(newSlot(newQueuedFio("d", "team-b", "", 1, false)), running)).To(BeFalse())
+	})
+
+	It("should admit the queued benchmarks in the order of their creation", func() {
+		queue := NewQueue(ConcurrencyLimits{Global: 2})
+		running := []slot{newSlot(newQueuedFio("a", "team-a", "", 0, false))}
+		first := newQueuedFio("b", "team-a", "", 1, false)
+		second := newQueuedFio("c", "team-a", "", 2, false)
+		third := newQueuedFio("d", "team-a", "", 3, false)
+		queued := []slot{newSlot(third), newSlot(second), newSlot(first)}
+
+		Expect(queue.simulate(running, queued, first)).To(BeZero())
+		Expect(queue.simulate(running, queued, second)).To(Equal(int32(1)))
+		Expect(queue.simulate(running, queued, third)).To(Equal(int32(2)))
+	})
+
+	It("should not starve the exclusive benchmarks", func() {
+		queue := NewQueue(ConcurrencyLimits{})
+		running := []slot{newSlot(newQueuedFio("a", "team-a", "node-1", 0, false))}
+		exclusive := newQueuedFio("b", "team-a", "node-1", 1, true)
+		later := newQueuedFio("c", "team-a", "node-1", 2, false)
+		elsewhere := newQueuedFio("d", "team-a", "node-2", 3, false)
+		queued := []slot{newSlot(exclusive), newSlot(later), newSlot(elsewhere)}
+
+		Expect(queue.simulate(running, queued, exclusive)).To(Equal(int32(1)))
+		Expect(queue.simulate(running, queued, later)).To(Equal(int32(2)))
+		Expect(queue.simulate(running, queued, elsewhere)).To(BeZero())
+	})
+})
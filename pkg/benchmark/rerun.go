@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// RerunAnnotation starts a new run of the finished benchmark whenever
+// its value changes, e.g. kubestone.xridge.io/rerun=$(date +%s)
+const RerunAnnotation = "kubestone.xridge.io/rerun"
+
+// rerunReason returns why the finished benchmark has to run again, or
+// an empty string if it does not
+func rerunReason(cr Object) string {
+	if isCancelled(cr) {
+		return ""
+	}
+	status := cr.GetBenchmarkStatus()
+	if status.Phase == perfv1alpha1.BenchmarkCancelled {
+		return "the benchmark has been resumed"
+	}
+	if status.ObservedSpecHash != "" {
+		if specHash(cr) != status.ObservedSpecHash {
+			return "the spec has changed"
+		}
+	} else if status.ObservedGeneration != 0 && cr.GetGeneration() != status.ObservedGeneration {
+		// The benchmarks started before the hash of the spec was
+		// recorded are compared by their generation
+		return "the spec has changed"
+	}
+	if cr.GetAnnotations()[RerunAnnotation] != status.ObservedRerun {
+		return "a rerun has been requested"
+	}
+	return ""
+}
+
+// specHash returns the hash of the fields of the spec defining the
+// benchmark. The fields common to every benchmark (thresholds, sinks,
+// timeout, exclusivity and the lifecycle settings) and the labels and
+// annotations of the pods are left out, so changing them does not start
+// a new run.
+func specHash(cr Object) string {
+	copied := cr.DeepCopyObject().(Object)
+	*copied.GetBenchmarkSpec() = perfv1alpha1.BenchmarkSpec{}
+	clearPodMetadata(reflect.ValueOf(copied))
+
+	data, err := json.Marshal(copied)
+	if err != nil {
+		return ""
+	}
+	var object struct {
+		Spec json.RawMessage `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(object.Spec))
+}
+
+// clearPodMetadata clears the labels and annotations of every pod
+// configuration found in the given value
+func clearPodMetadata(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			clearPodMetadata(value.Elem())
+		}
+	case reflect.Struct:
+		if podConfig, ok := value.Addr().Interface().(*perfv1alpha1.PodConfigurationSpec); ok {
+			podConfig.Annotations = nil
+			podConfig.PodLabels = nil
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Field(i).CanSet() {
+				clearPodMetadata(value.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			clearPodMetadata(value.Index(i))
+		}
+	}
+}
+
+// rerun moves the finished run of the benchmark to the history and resets
+// its status, so the next reconciliation starts a new run. The jobs of the
+// new run get unique names, while the other objects of the previous run
+// are deleted to be recreated from the current spec.
+func (e *Engine) rerun(ctx context.Context, cr Object, reason string) (ctrl.Result, error) {
+	if err := e.K8S.DeleteControlledObjects(ctx, cr, isRecreated); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	status := cr.GetBenchmarkStatus()
+	for _, run := range status.StartNewRun() {
+		if run.Logs == nil {
+			continue
+		}
+		archive := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      run.Logs.ConfigMap,
+			Namespace: cr.GetNamespace(),
+		}}
+		if err := e.K8S.DeleteObject(ctx, archive, cr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	_ = e.K8S.RecordEventf(cr, corev1.EventTypeNormal, k8s.Rerun,
+		"Starting run %d as %s", status.Run, reason)
+	return ctrl.Result{}, e.K8S.Client.Status().Update(ctx, cr)
+}
+
+// isRecreated returns true for the objects of the previous run which are
+// recreated for the next one. The PersistentVolumeClaims are reused, as
+// they are protected from deletion while the pods of the previous run
+// are terminating.
+func isRecreated(object metav1.Object) bool {
+	if _, ok := object.(*corev1.PersistentVolumeClaim); ok {
+		return false
+	}
+	return isDisposable(object)
+}
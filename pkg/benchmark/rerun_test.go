@@ -0,0 +1,162 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Rerun", func() {
+	var cr *perfv1alpha1.Fio
+
+	BeforeEach(func() {
+		cr = &perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fio-sample", Generation: 1},
+			Status: perfv1alpha1.BenchmarkStatus{
+				Phase:              perfv1alpha1.BenchmarkSucceeded,
+				Run:                1,
+				ObservedGeneration: 1,
+			},
+		}
+	})
+
+	It("should not rerun an unchanged benchmark", func() {
+		Expect(rerunReason(cr)).To(BeEmpty())
+	})
+
+	It("should rerun when the spec has changed", func() {
+		cr.Status.ObservedSpecHash = specHash(cr)
+		cr.Generation = 2
+		cr.Spec.BuiltinJobFiles = []string{"/jobs/rand-write.fio"}
+		Expect(rerunReason(cr)).NotTo(BeEmpty())
+	})
+
+	It("should not rerun when only the lifecycle of the benchmark has changed", func() {
+		cr.Status.ObservedSpecHash = specHash(cr)
+		cr.Generation = 2
+		cr.Spec.Suspend = true
+		cr.Spec.CleanupPolicy = perfv1alpha1.CleanupAlways
+		Expect(rerunReason(cr)).To(BeEmpty())
+
+		cr.Spec.Suspend = false
+		Expect(rerunReason(cr)).To(BeEmpty())
+	})
+
+	Context("when only an operational field has changed", func() {
+		BeforeEach(func() {
+			cr.Spec.PodConfig.PodLabels = map[string]string{"team": "storage"}
+			cr.Status.ObservedSpecHash = specHash(cr)
+			cr.Generation = 2
+		})
+
+		It("should not rerun for new sinks", func() {
+			cr.Spec.Sinks = []perfv1alpha1.ResultSink{{ConfigMap: &perfv1alpha1.ConfigMapSink{}}}
+			Expect(rerunReason(cr)).To(BeEmpty())
+		})
+
+		It("should not rerun for new thresholds", func() {
+			cr.Spec.Thresholds = []perfv1alpha1.MetricThreshold{{Metric: "iops"}}
+			Expect(rerunReason(cr)).To(BeEmpty())
+		})
+
+		It("should not rerun for a new timeout", func() {
+			cr.Spec.Timeout = &metav1.Duration{Duration: time.Hour}
+			Expect(rerunReason(cr)).To(BeEmpty())
+		})
+
+		It("should not rerun when the benchmark becomes exclusive", func() {
+			cr.Spec.Exclusive = true
+			Expect(rerunReason(cr)).To(BeEmpty())
+		})
+
+		It("should not rerun for a new TTL", func() {
+			ttl := int32(60)
+			cr.Spec.TTLSecondsAfterFinished = &ttl
+			Expect(rerunReason(cr)).To(BeEmpty())
+		})
+
+		It("should not rerun for new pod labels and annotations", func() {
+			cr.Spec.PodConfig.PodLabels["team"] = "network"
+			cr.Spec.PodConfig.Annotations = map[string]string{"owner": "jane"}
+			Expect(rerunReason(cr)).To(BeEmpty())
+		})
+
+		It("should rerun for new pod resources", func() {
+			cr.Spec.PodConfig.Resources.Limits = corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			}
+			Expect(rerunReason(cr)).NotTo(BeEmpty())
+		})
+	})
+
+	It("should ignore the pod labels of the nested pod configurations", func() {
+		iperf3 := &perfv1alpha1.Iperf3{}
+		hash := specHash(iperf3)
+		iperf3.Spec.ClientConfiguration.PodLabels = map[string]string{"role": "client"}
+		iperf3.Spec.ServerConfiguration.Annotations = map[string]string{"role": "server"}
+		Expect(specHash(iperf3)).To(Equal(hash))
+
+		iperf3.Spec.ClientConfiguration.CmdLineArgs = "--time 20"
+		Expect(specHash(iperf3)).NotTo(Equal(hash))
+	})
+
+	It("should compare the generation of the benchmarks started without a spec hash", func() {
+		cr.Generation = 2
+		Expect(rerunReason(cr)).NotTo(BeEmpty())
+	})
+
+	It("should rerun when the annotation has changed", func() {
+		cr.Annotations = map[string]string{RerunAnnotation: "1"}
+		Expect(rerunReason(cr)).NotTo(BeEmpty())
+
+		cr.Status.ObservedRerun = "1"
+		Expect(rerunReason(cr)).To(BeEmpty())
+	})
+
+	It("should reuse the persistent volume claims", func() {
+		Expect(isRecreated(&corev1.PersistentVolumeClaim{})).To(BeFalse())
+		Expect(isRecreated(&corev1.Service{})).To(BeTrue())
+	})
+
+	It("should name the objects of the subsequent runs uniquely", func() {
+		Expect(perfv1alpha1.RunName("fio-sample", 1)).To(Equal("fio-sample"))
+		Expect(perfv1alpha1.RunName("fio-sample", 3)).To(Equal("fio-sample-run3"))
+	})
+
+	It("should keep a bounded history of the runs", func() {
+		status := &cr.Status
+		var dropped []perfv1alpha1.BenchmarkRun
+		for i := 0; i < perfv1alpha1.MaxRunHistory+2; i++ {
+			status.Phase = perfv1alpha1.BenchmarkSucceeded
+			dropped = status.StartNewRun()
+		}
+		Expect(status.Run).To(Equal(int32(perfv1alpha1.MaxRunHistory + 3)))
+		Expect(status.Phase).To(BeEmpty())
+		Expect(status.History).To(HaveLen(perfv1alpha1.MaxRunHistory))
+		Expect(status.History[0].Run).To(Equal(int32(perfv1alpha1.MaxRunHistory + 2)))
+		Expect(dropped).To(HaveLen(1))
+		Expect(dropped[0].Run).To(Equal(int32(2)))
+	})
+})
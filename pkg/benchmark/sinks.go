@@ -0,0 +1,221 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+	"github.com/xridge/kubestone/pkg/sinks"
+)
+
+// archiveLogs collects the output of every container of the finished
+// benchmark and stores it in a ConfigMap controlled by the CR. The
+// collected logs are returned for the sinks along with the reference
+// to the archive. Failures are reported as events only.
+func (e *Engine) archiveLogs(ctx context.Context, cr Object) (map[string]string, *perfv1alpha1.LogArchive) {
+	logs, err := sinks.CollectLogs(e.K8S, cr)
+	if err != nil {
+		e.Log.Error(err, "Unable to collect the logs of the benchmark")
+		_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.LogArchiveFailed,
+			"Failed to collect the logs of the benchmark: %v", err)
+		return nil, nil
+	}
+	if len(logs) == 0 {
+		return logs, nil
+	}
+
+	archive, err := sinks.StoreLogArchive(ctx, e.K8S, cr, cr.GetBenchmarkStatus().Run, logs)
+	if err != nil {
+		e.Log.Error(err, "Unable to archive the logs of the benchmark")
+		_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.LogArchiveFailed,
+			"Failed to archive the logs of the benchmark: %v", err)
+	}
+	return logs, archive
+}
+
+// deliverResults sends the logs and the status of the finished benchmark
+// CR to the sinks of the CR and records the outcome of each delivery in
+// the status. The deliveries finishing asynchronously are left pending
+// and followed by trackDeliveries. A failed delivery does not change the
+// outcome of the benchmark.
+func (e *Engine) deliverResults(ctx context.Context, cr Object, logs map[string]string) {
+	status := cr.GetBenchmarkStatus()
+	gvk, err := apiutil.GVKForObject(cr, e.K8S.Scheme)
+	if err != nil {
+		e.Log.Error(err, "Unable to deliver the results")
+		return
+	}
+	payload := sinks.Payload{
+		Kind:      gvk.Kind,
+		Namespace: cr.GetNamespace(),
+		Name:      cr.GetName(),
+		Run:       status.Run,
+		Labels:    cr.GetLabels(),
+		Status:    status,
+		Logs:      logs,
+	}
+
+	status.Sinks = nil
+	for i, spec := range cr.GetBenchmarkSpec().Sinks {
+		sinkStatus := perfv1alpha1.ResultSinkStatus{Type: sinks.TypeOf(spec)}
+		sink, err := sinks.New(e.K8S, cr, status.Run, spec, i, e.Images.Default)
+		if err == nil {
+			sinkStatus.Location, err = sink.Store(ctx, &payload)
+		}
+
+		if _, tracked := sink.(sinks.Tracker); tracked && err == nil {
+			sinkStatus.Pending = true
+		} else {
+			e.recordDelivery(cr, &sinkStatus, err)
+		}
+		status.Sinks = append(status.Sinks, sinkStatus)
+	}
+	setDeliveredCondition(status)
+}
+
+// trackDeliveries records the outcome of the pending deliveries which
+// have finished since the last reconciliation
+func (e *Engine) trackDeliveries(ctx context.Context, cr Object) error {
+	status := cr.GetBenchmarkStatus()
+	finished := 0
+	for i := range status.Sinks {
+		sinkStatus := &status.Sinks[i]
+		if !sinkStatus.Pending {
+			continue
+		}
+		delivered, err := e.delivered(ctx, cr, i)
+		if !delivered {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		e.recordDelivery(cr, sinkStatus, err)
+		finished++
+	}
+	if finished == 0 {
+		return nil
+	}
+
+	setDeliveredCondition(status)
+	return e.recordDeliveries(ctx, cr)
+}
+
+// delivered returns whether the pending delivery to the sink with the
+// given index has finished, along with the reason of its failure
+func (e *Engine) delivered(ctx context.Context, cr Object, index int) (bool, error) {
+	status := cr.GetBenchmarkStatus()
+	specs := cr.GetBenchmarkSpec().Sinks
+	if index >= len(specs) || sinks.TypeOf(specs[index]) != status.Sinks[index].Type {
+		return true, fmt.Errorf("the %s sink has been removed from the spec", status.Sinks[index].Type)
+	}
+
+	sink, err := sinks.New(e.K8S, cr, status.Run, specs[index], index, e.Images.Default)
+	if err != nil {
+		return true, err
+	}
+	tracker, ok := sink.(sinks.Tracker)
+	if !ok {
+		return true, nil
+	}
+	return tracker.Delivered(ctx)
+}
+
+// recordDelivery records the outcome of a finished delivery as an event
+// and in the status of the sink
+func (e *Engine) recordDelivery(cr Object, sinkStatus *perfv1alpha1.ResultSinkStatus, err error) {
+	sinkStatus.Pending = false
+	if err != nil {
+		e.Log.Error(err, "Unable to deliver the results", "sink", sinkStatus.Type)
+		_ = e.K8S.RecordEventf(cr, corev1.EventTypeWarning, k8s.SinkFailed,
+			"Failed to deliver the results to the %s sink: %v", sinkStatus.Type, err)
+		sinkStatus.Error = err.Error()
+		return
+	}
+	_ = e.K8S.RecordEventf(cr, corev1.EventTypeNormal, k8s.ResultsStored,
+		"Results delivered to %s", sinkStatus.Location)
+}
+
+// setDeliveredCondition sets the ResultsDelivered condition from the
+// status of the sinks. It is left unknown while deliveries are pending.
+func setDeliveredCondition(status *perfv1alpha1.BenchmarkStatus) {
+	failed, pending := 0, 0
+	for _, sinkStatus := range status.Sinks {
+		switch {
+		case sinkStatus.Pending:
+			pending++
+		case sinkStatus.Error != "":
+			failed++
+		}
+	}
+
+	switch {
+	case pending > 0:
+		status.SetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered, corev1.ConditionUnknown,
+			"Delivering", fmt.Sprintf("%d of %d deliveries are in progress", pending, len(status.Sinks)))
+	case failed > 0:
+		status.SetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered, corev1.ConditionFalse,
+			"DeliveryFailed", fmt.Sprintf("%d of %d deliveries failed", failed, len(status.Sinks)))
+	default:
+		status.SetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered, corev1.ConditionTrue,
+			"Delivered", "The results have been delivered to the sinks")
+	}
+}
+
+// hasPendingDeliveries returns true if some of the deliveries of the
+// results of the CR have not finished yet
+func hasPendingDeliveries(cr Object) bool {
+	for _, sinkStatus := range cr.GetBenchmarkStatus().Sinks {
+		if sinkStatus.Pending {
+			return true
+		}
+	}
+	return false
+}
+
+// recordDeliveries persists the outcome of the deliveries. As the results
+// must not be delivered again, conflicting updates are retried on the
+// latest version of the CR instead of requeueing the reconciliation.
+func (e *Engine) recordDeliveries(ctx context.Context, cr Object) error {
+	deliveries := cr.GetBenchmarkStatus().Sinks
+	delivered := *cr.GetBenchmarkStatus().GetCondition(perfv1alpha1.BenchmarkConditionResultsDelivered)
+	key := types.NamespacedName{Namespace: cr.GetNamespace(), Name: cr.GetName()}
+
+	conflicted := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if conflicted {
+			if err := e.K8S.Client.Get(ctx, key, cr); err != nil {
+				return err
+			}
+			status := cr.GetBenchmarkStatus()
+			status.Sinks = deliveries
+			status.SetCondition(delivered.Type, delivered.Status, delivered.Reason, delivered.Message)
+		}
+		err := e.K8S.Client.Status().Update(ctx, cr)
+		conflicted = errors.IsConflict(err)
+		return err
+	})
+}
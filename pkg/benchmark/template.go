@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// TemplateKinds are the kinds of benchmark CRs which can be described
+// by a BenchmarkTemplate
+var TemplateKinds = []string{
+	"Drill", "EsRally", "Fio", "Ioping", "Iperf3", "JMeter", "KafkaBench",
+	"OcpLogtest", "Pgbench", "Qperf", "S3Bench", "Sysbench", "YcsbBench",
+}
+
+// ChildName returns the name of a benchmark CR created by the CR parent:
+// <parent>-<suffix>. As the name of the benchmark CR ends up in the label
+// values of its pods, names longer than a label value are truncated and
+// made unique again by a hash of the full name.
+func ChildName(parent, suffix string) string {
+	name := parent + "-" + suffix
+	if len(name) <= validation.DNS1123LabelMaxLength {
+		return name
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	hashSuffix := fmt.Sprintf("-%08x", hash.Sum32())
+	prefix := strings.TrimRight(name[:validation.DNS1123LabelMaxLength-len(hashSuffix)], "-.")
+	return prefix + hashSuffix
+}
+
+// NewFromTemplate returns the benchmark CR described by the template with
+// the given metadata. The labels of the template are added to the metadata.
+// Unknown fields in the spec of the template are reported as errors.
+func NewFromTemplate(scheme *runtime.Scheme, template *perfv1alpha1.BenchmarkTemplate,
+	objectMeta metav1.ObjectMeta) (Object, error) {
+	runtimeObject, err := scheme.New(perfv1alpha1.GroupVersion.WithKind(template.Kind))
+	if err != nil {
+		return nil, err
+	}
+	cr, ok := runtimeObject.(Object)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a benchmark kind", template.Kind)
+	}
+
+	raw := template.Spec.Raw
+	if len(raw) == 0 {
+		raw = []byte("{}")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(
+		append(append([]byte(`{"spec":`), raw...), '}')))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cr); err != nil {
+		return nil, fmt.Errorf("invalid %s spec: %v", template.Kind, err)
+	}
+
+	labels := map[string]string{}
+	for key, value := range objectMeta.Labels {
+		labels[key] = value
+	}
+	for key, value := range template.Labels {
+		labels[key] = value
+	}
+	cr.SetName(objectMeta.Name)
+	cr.SetNamespace(objectMeta.Namespace)
+	cr.SetLabels(labels)
+	cr.SetAnnotations(objectMeta.Annotations)
+	return cr, nil
+}
+
+// RunFromTemplate creates the benchmark CR described by the template with
+// owner as its controller, unless it exists already, and returns the
+// outcome of the benchmark CR. Benchmark CRs which are not visible yet
+// are reported with an empty phase. An existing benchmark CR which is not
+// controlled by the owner is reported as failed.
+func RunFromTemplate(ctx context.Context, access *k8s.Access, owner metav1.Object,
+	template *perfv1alpha1.BenchmarkTemplate, objectMeta metav1.ObjectMeta) (perfv1alpha1.BenchmarkOutcome, error) {
+	outcome := perfv1alpha1.BenchmarkOutcome{Name: objectMeta.Name, Kind: template.Kind}
+
+	cr, err := NewFromTemplate(access.Scheme, template, objectMeta)
+	if err != nil {
+		return outcome, err
+	}
+	err = access.CreateWithReference(ctx, cr, owner)
+	if errors.IsInvalid(err) || errors.IsForbidden(err) {
+		// The benchmark CR is rejected by the API server (or by its
+		// admission webhooks), retrying would not help
+		outcome.Phase = perfv1alpha1.BenchmarkFailed
+		outcome.Message = "Unable to create benchmark: " + err.Error()
+		return outcome, nil
+	} else if err != nil {
+		return outcome, err
+	}
+
+	// Fetch into a fresh object, so that the owner reference set above is
+	// not mistaken for the one of an existing benchmark CR
+	runtimeObject, err := access.Scheme.New(perfv1alpha1.GroupVersion.WithKind(template.Kind))
+	if err != nil {
+		return outcome, err
+	}
+	existing := runtimeObject.(Object)
+	err = access.Client.Get(ctx, types.NamespacedName{
+		Namespace: cr.GetNamespace(),
+		Name:      cr.GetName(),
+	}, existing)
+	if errors.IsNotFound(err) {
+		return outcome, nil
+	} else if err != nil {
+		return outcome, err
+	}
+
+	if !metav1.IsControlledBy(existing, owner) {
+		outcome.Phase = perfv1alpha1.BenchmarkFailed
+		outcome.Message = fmt.Sprintf("Unable to create benchmark: %s %s exists already and is not controlled by %s",
+			template.Kind, existing.GetName(), owner.GetName())
+		return outcome, nil
+	}
+
+	status := existing.GetBenchmarkStatus()
+	outcome.Phase = status.Phase
+	outcome.Message = status.Message
+	outcome.Results = status.Results
+	return outcome, nil
+}
+
+// OwnsBenchmarks configures the controller to watch the benchmark CRs of
+// all template kinds which are controlled by the CRs of the controller
+func OwnsBenchmarks(b *builder.Builder, scheme *runtime.Scheme) (*builder.Builder, error) {
+	for _, kind := range TemplateKinds {
+		object, err := scheme.New(perfv1alpha1.GroupVersion.WithKind(kind))
+		if err != nil {
+			return nil, err
+		}
+		b = b.Owns(object)
+	}
+	return b, nil
+}
+
+// ListControlled returns the benchmark CRs of the given kind with the
+// given labels which are controlled by the owner
+func ListControlled(ctx context.Context, access *k8s.Access, owner metav1.Object,
+	kind string, labels map[string]string) ([]Object, error) {
+	list, err := access.Scheme.New(perfv1alpha1.GroupVersion.WithKind(kind + "List"))
+	if err != nil {
+		return nil, err
+	}
+	if err := access.Client.List(ctx, list, client.InNamespace(owner.GetNamespace()),
+		client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var crs []Object
+	for _, item := range items {
+		cr, ok := item.(Object)
+		if ok && metav1.IsControlledBy(cr, owner) {
+			crs = append(crs, cr)
+		}
+	}
+	return crs, nil
+}
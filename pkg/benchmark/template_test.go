@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+var _ = Describe("NewFromTemplate", func() {
+	var scheme *runtime.Scheme
+	var template perfv1alpha1.BenchmarkTemplate
+	var objectMeta metav1.ObjectMeta
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(perfv1alpha1.AddToScheme(scheme)).To(Succeed())
+		template = perfv1alpha1.BenchmarkTemplate{
+			Kind:   "Sysbench",
+			Labels: map[string]string{"suite": "cpu"},
+			Spec: runtime.RawExtension{
+				Raw: []byte(`{"testName":"cpu","command":"run","options":"--threads=1"}`),
+			},
+		}
+		objectMeta = metav1.ObjectMeta{
+			Name:      "sample-sysbench",
+			Namespace: "kubestone",
+			Labels:    map[string]string{"owner": "sample"},
+		}
+	})
+
+	It("should create the CR of the kind with the spec of the template", func() {
+		cr, err := NewFromTemplate(scheme, &template, objectMeta)
+		Expect(err).To(BeNil())
+		sysbench, ok := cr.(*perfv1alpha1.Sysbench)
+		Expect(ok).To(BeTrue())
+		Expect(sysbench.Spec.TestName).To(Equal("cpu"))
+		Expect(sysbench.Spec.Command).To(Equal("run"))
+		Expect(sysbench.Spec.Options).To(Equal("--threads=1"))
+	})
+
+	It("should use the given metadata and add the labels of the template", func() {
+		cr, err := NewFromTemplate(scheme, &template, objectMeta)
+		Expect(err).To(BeNil())
+		Expect(cr.GetName()).To(Equal("sample-sysbench"))
+		Expect(cr.GetNamespace()).To(Equal("kubestone"))
+		Expect(cr.GetLabels()).To(Equal(map[string]string{
+			"owner": "sample",
+			"suite": "cpu",
+		}))
+		Expect(objectMeta.Labels).To(HaveLen(1))
+	})
+
+	It("should reject unknown fields in the spec", func() {
+		template.Spec.Raw = []byte(`{"testName":"cpu","thread":1}`)
+		_, err := NewFromTemplate(scheme, &template, objectMeta)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject unknown kinds", func() {
+		template.Kind = "Geekbench"
+		_, err := NewFromTemplate(scheme, &template, objectMeta)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject kinds which are not benchmarks", func() {
+		template.Kind = "SysbenchList"
+		_, err := NewFromTemplate(scheme, &template, objectMeta)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should know every template kind", func() {
+		for _, kind := range TemplateKinds {
+			template.Kind = kind
+			template.Spec.Raw = nil
+			_, err := NewFromTemplate(scheme, &template, objectMeta)
+			Expect(err).To(BeNil(), kind)
+		}
+	})
+})
+
+var _ = Describe("ChildName", func() {
+	It("should join the names of the parent and the child", func() {
+		Expect(ChildName("sample", "fio")).To(Equal("sample-fio"))
+	})
+
+	It("should shorten the names exceeding the length of label values", func() {
+		parent := strings.Repeat("a", 60)
+		name := ChildName(parent, "fio")
+		Expect(validation.IsValidLabelValue(name)).To(BeEmpty())
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+		Expect(name).To(HavePrefix(parent[:50]))
+		Expect(name).NotTo(Equal(ChildName(parent, "sysbench")))
+	})
+})
+
+var _ = Describe("RunFromTemplate", func() {
+	var access *k8s.Access
+	var owner *perfv1alpha1.BenchmarkSuite
+	var template perfv1alpha1.BenchmarkTemplate
+	objectMeta := metav1.ObjectMeta{Name: "sample-sysbench", Namespace: "kubestone"}
+
+	// newAccess returns the access to a fake cluster with the given objects
+	newAccess := func(objects ...runtime.Object) *k8s.Access {
+		scheme := runtime.NewScheme()
+		Expect(k8sscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(perfv1alpha1.AddToScheme(scheme)).To(Succeed())
+		return &k8s.Access{
+			Client:        fake.NewFakeClientWithScheme(scheme, objects...),
+			Scheme:        scheme,
+			EventRecorder: record.NewFakeRecorder(10),
+		}
+	}
+
+	BeforeEach(func() {
+		owner = &perfv1alpha1.BenchmarkSuite{
+			ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "kubestone", UID: "sample-uid"},
+		}
+		template = perfv1alpha1.BenchmarkTemplate{
+			Kind: "Sysbench",
+			Spec: runtime.RawExtension{Raw: []byte(`{"testName":"cpu","command":"run"}`)},
+		}
+	})
+
+	It("should create the benchmark CR controlled by the owner", func() {
+		access = newAccess(owner)
+		outcome, err := RunFromTemplate(context.Background(), access, owner, &template, objectMeta)
+		Expect(err).To(BeNil())
+		Expect(outcome.Finished()).To(BeFalse())
+		Expect(outcome.Name).To(Equal("sample-sysbench"))
+	})
+
+	It("should fail when a benchmark CR not controlled by the owner exists already", func() {
+		existing := &perfv1alpha1.Sysbench{ObjectMeta: objectMeta}
+		existing.Status.MarkSucceeded("Benchmark job completed")
+		access = newAccess(owner, existing)
+		outcome, err := RunFromTemplate(context.Background(), access, owner, &template, objectMeta)
+		Expect(err).To(BeNil())
+		Expect(outcome.Phase).To(Equal(perfv1alpha1.BenchmarkFailed))
+		Expect(outcome.Message).To(ContainSubstring("not controlled by sample"))
+	})
+})
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"math"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// TimedOutReason is the reason of the failure of the benchmarks which
+// have not finished within their timeout
+const TimedOutReason = "TimedOut"
+
+// jobDeadlineExceeded is the reason of the failure of the jobs which
+// have reached their active deadline
+const jobDeadlineExceeded = "DeadlineExceeded"
+
+// deadlineOf returns the time by which the benchmark has to finish, or
+// nil if the benchmark has no timeout
+func deadlineOf(cr Object) *time.Time {
+	timeout := cr.GetBenchmarkSpec().Timeout
+	status := cr.GetBenchmarkStatus()
+	if timeout == nil || status.StartTime == nil {
+		return nil
+	}
+	deadline := status.StartTime.Add(timeout.Duration)
+	return &deadline
+}
+
+// setActiveDeadline limits the runtime of the job to the time left until
+// the deadline, unless the job has a shorter deadline already
+func setActiveDeadline(job *batchv1.Job, deadline time.Time) {
+	seconds := int64(math.Ceil(time.Until(deadline).Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds > seconds {
+		job.Spec.ActiveDeadlineSeconds = &seconds
+	}
+}
+
+// waitUntil makes sure that the benchmark is reconciled again at the
+// deadline at the latest, even if none of its objects change
+func waitUntil(result ctrl.Result, deadline *time.Time) ctrl.Result {
+	if deadline == nil {
+		return result
+	}
+	// Requeue slightly after the deadline, so it has surely passed
+	remaining := time.Until(*deadline) + time.Second
+	if result.RequeueAfter == 0 || remaining < result.RequeueAfter {
+		result.RequeueAfter = remaining
+	}
+	return result
+}
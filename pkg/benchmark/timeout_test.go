@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Timeout", func() {
+	var cr *perfv1alpha1.Fio
+	start := metav1.NewTime(time.Date(2019, 11, 1, 2, 0, 0, 0, time.UTC))
+
+	BeforeEach(func() {
+		cr = &perfv1alpha1.Fio{
+			Spec:   perfv1alpha1.FioSpec{BuiltinJobFiles: []string{"/jobs/rand-read.fio"}},
+			Status: perfv1alpha1.BenchmarkStatus{StartTime: &start},
+		}
+	})
+
+	It("should not have a deadline without a timeout", func() {
+		Expect(deadlineOf(cr)).To(BeNil())
+	})
+
+	It("should have a deadline relative to the start of the benchmark", func() {
+		cr.Spec.Timeout = &metav1.Duration{Duration: 30 * time.Minute}
+		Expect(*deadlineOf(cr)).To(Equal(start.Add(30 * time.Minute)))
+	})
+
+	It("should reject non-positive timeouts", func() {
+		cr.Spec.Timeout = &metav1.Duration{Duration: -time.Minute}
+		Expect(validate(fioBenchmark{}, cr)).NotTo(Succeed())
+	})
+
+	It("should limit the jobs to the time left", func() {
+		job := batchv1.Job{}
+		setActiveDeadline(&job, time.Now().Add(10*time.Minute))
+		Expect(*job.Spec.ActiveDeadlineSeconds).To(BeNumerically("~", 600, 1))
+
+		shorter := int64(60)
+		job.Spec.ActiveDeadlineSeconds = &shorter
+		setActiveDeadline(&job, time.Now().Add(10*time.Minute))
+		Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(60)))
+
+		job.Spec.ActiveDeadlineSeconds = nil
+		setActiveDeadline(&job, time.Now().Add(-time.Minute))
+		Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(1)))
+	})
+
+	It("should requeue at the deadline at the latest", func() {
+		deadline := time.Now().Add(time.Minute)
+		Expect(waitUntil(ctrl.Result{}, nil)).To(Equal(ctrl.Result{}))
+		Expect(waitUntil(ctrl.Result{}, &deadline).RequeueAfter).To(BeNumerically("~", 61*time.Second, time.Second))
+		Expect(waitUntil(ctrl.Result{RequeueAfter: 5 * time.Second}, &deadline).RequeueAfter).To(Equal(5 * time.Second))
+	})
+})
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validatingHandler admits the benchmark CRs which pass the same
+// validation as the one executed by the Engine, except for the lookups
+// of the referred objects (see ReferenceValidator)
+type validatingHandler struct {
+	benchmark Benchmark
+	decoder   *admission.Decoder
+}
+
+// InjectDecoder injects the decoder of the admission requests
+func (h *validatingHandler) InjectDecoder(decoder *admission.Decoder) error {
+	h.decoder = decoder
+	return nil
+}
+
+// Handle decodes the CR from the request and validates it. The updates
+// leaving the spec unchanged (e.g. setting an annotation) are allowed
+// without validating the CR again.
+func (h *validatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	cr := h.benchmark.NewObject()
+	if err := h.decoder.Decode(req, cr); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if req.Operation == admissionv1beta1.Update && !specChanged(req) {
+		return admission.Allowed("")
+	}
+
+	if err := validate(h.benchmark, cr); err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// specChanged returns true if the update request changes the spec of the CR
+func specChanged(req admission.Request) bool {
+	var object, old struct {
+		Spec interface{} `json:"spec"`
+	}
+	if json.Unmarshal(req.Object.Raw, &object) != nil || json.Unmarshal(req.OldObject.Raw, &old) != nil {
+		return true
+	}
+	return !reflect.DeepEqual(object.Spec, old.Spec)
+}
+
+// SetupValidatingWebhook registers a validating admission webhook for the
+// CRs of the benchmark, so invalid specs are rejected by the API server
+// instead of failing during the reconciliation.
+func SetupValidatingWebhook(mgr ctrl.Manager, path string, b Benchmark) error {
+	mgr.GetWebhookServer().Register(path, &webhook.Admission{
+		Handler: &validatingHandler{benchmark: b},
+	})
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// fioBenchmark accepts the Fio CRs with at least one builtin job
+// and defaults their image
+type fioBenchmark struct{}
+
+func (fioBenchmark) NewObject() Object {
+	return &perfv1alpha1.Fio{}
+}
+
+func (fioBenchmark) Validate(cr Object) error {
+	if len(cr.(*perfv1alpha1.Fio).Spec.BuiltinJobFiles) == 0 {
+		return errors.New("no builtin job files")
+	}
+	return nil
+}
+
+func (fioBenchmark) Steps(cr Object) ([]Step, error) {
+	return nil, nil
+}
+
+func (fioBenchmark) Default(cr Object) {
+	DefaultImageCatalog().Default("fio", &cr.(*perfv1alpha1.Fio).Spec.Image)
+}
+
+func newDecoder() *admission.Decoder {
+	scheme := runtime.NewScheme()
+	Expect(perfv1alpha1.AddToScheme(scheme)).To(Succeed())
+	decoder, err := admission.NewDecoder(scheme)
+	Expect(err).NotTo(HaveOccurred())
+	return decoder
+}
+
+func newRequest(cr *perfv1alpha1.Fio) admission.Request {
+	cr.TypeMeta = metav1.TypeMeta{
+		APIVersion: perfv1alpha1.GroupVersion.String(),
+		Kind:       "Fio",
+	}
+	raw, err := json.Marshal(cr)
+	Expect(err).NotTo(HaveOccurred())
+	return admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}}
+}
+
+var _ = Describe("validating webhook", func() {
+	var handler *validatingHandler
+
+	BeforeEach(func() {
+		handler = &validatingHandler{benchmark: fioBenchmark{}}
+		Expect(handler.InjectDecoder(newDecoder())).To(Succeed())
+	})
+
+	Context("with a valid CR", func() {
+		It("should allow the request", func() {
+			cr := &perfv1alpha1.Fio{Spec: perfv1alpha1.FioSpec{
+				BuiltinJobFiles: []string{"/jobs/rand-read.fio"},
+			}}
+			response := handler.Handle(context.Background(), newRequest(cr))
+			Expect(response.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("with an invalid CR", func() {
+		It("should deny the request with the validation error", func() {
+			response := handler.Handle(context.Background(), newRequest(&perfv1alpha1.Fio{}))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Reason).To(Equal(metav1.StatusReason("no builtin job files")))
+		})
+	})
+
+	Context("with invalid thresholds", func() {
+		It("should deny the request", func() {
+			cr := &perfv1alpha1.Fio{Spec: perfv1alpha1.FioSpec{
+				BuiltinJobFiles: []string{"/jobs/rand-read.fio"},
+				BenchmarkSpec: perfv1alpha1.BenchmarkSpec{
+					Thresholds: []perfv1alpha1.MetricThreshold{
+						{Metric: "iops", Operator: perfv1alpha1.GreaterThan, Value: "many"},
+					},
+				},
+			}}
+			response := handler.Handle(context.Background(), newRequest(cr))
+			Expect(response.Allowed).To(BeFalse())
+		})
+	})
+
+	Context("with an update", func() {
+		// newUpdate returns the request updating the old CR to cr
+		newUpdate := func(old, cr *perfv1alpha1.Fio) admission.Request {
+			req := newRequest(cr)
+			req.Operation = admissionv1beta1.Update
+			req.OldObject = newRequest(old).Object
+			return req
+		}
+
+		It("should allow the request leaving the spec unchanged", func() {
+			old := &perfv1alpha1.Fio{}
+			cr := old.DeepCopy()
+			cr.Annotations = map[string]string{RerunAnnotation: "1"}
+			response := handler.Handle(context.Background(), newUpdate(old, cr))
+			Expect(response.Allowed).To(BeTrue())
+		})
+
+		It("should validate the changed spec", func() {
+			old := &perfv1alpha1.Fio{Spec: perfv1alpha1.FioSpec{
+				BuiltinJobFiles: []string{"/jobs/rand-read.fio"},
+			}}
+			response := handler.Handle(context.Background(), newUpdate(old, &perfv1alpha1.Fio{}))
+			Expect(response.Allowed).To(BeFalse())
+		})
+	})
+
+	Context("with a malformed object", func() {
+		It("should return an error", func() {
+			req := admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+				Object: runtime.RawExtension{Raw: []byte("{")},
+			}}
+			response := handler.Handle(context.Background(), req)
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(BeEquivalentTo(400))
+		})
+	})
+})
@@ -19,10 +19,13 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"io"
 	v1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -38,9 +41,14 @@ import (
 // Access provides client related structs to access kubernetes
 type Access struct {
 	Client        client.Client
-	Clientset     *k8sclient.Clientset
+	Clientset     k8sclient.Interface
 	Scheme        *runtime.Scheme
 	EventRecorder record.EventRecorder
+
+	// APIReader reads directly from the API server. It lists the types
+	// which are not watched by the manager, so that listing them does not
+	// start informers caching every one of them in the cluster.
+	APIReader client.Reader
 }
 
 // RecordEventf is a convenience function to create an event (via Access.EventRecorder)
@@ -112,7 +120,8 @@ func (a *Access) DeleteObject(ctx context.Context, object, owner metav1.Object)
 		return nil
 	}
 
-	err = a.Client.Delete(ctx, runtimeObject)
+	// The pods of Jobs are orphaned by default
+	err = a.Client.Delete(ctx, runtimeObject, client.PropagationPolicy(metav1.DeletePropagationBackground))
 	if IgnoreNotFound(err) != nil {
 		return err
 	}
@@ -127,14 +136,25 @@ func (a *Access) DeleteObject(ctx context.Context, object, owner metav1.Object)
 
 // IsJobFinished returns true if the given job has already succeeded or failed
 func (a *Access) IsJobFinished(namespacedName types.NamespacedName) (finished bool, err error) {
+	outcome, err := a.GetJobOutcome(namespacedName)
+	if err != nil {
+		return false, err
+	}
+
+	return outcome.Finished(), nil
+}
+
+// GetJobOutcome returns whether the given job is still running, has
+// succeeded or failed. For failed jobs the reason of the failure is
+// provided from the job conditions.
+func (a *Access) GetJobOutcome(namespacedName types.NamespacedName) (outcome JobOutcome, err error) {
 	job, err := a.Clientset.BatchV1().Jobs(namespacedName.Namespace).Get(
 		namespacedName.Name, metav1.GetOptions{})
 	if err != nil {
-		return false, err
+		return JobOutcome{}, err
 	}
 
-	finished = job.Status.CompletionTime != nil
-	return finished, nil
+	return NewJobOutcome(job), nil
 }
 
 func (a *Access) GetJob(namespacedName types.NamespacedName) *batchv1.Job {
@@ -161,6 +181,263 @@ func (a *Access) GetJobPods(namespacedName types.NamespacedName) (*corev1.PodLis
 		})
 }
 
+// +kubebuilder:rbac:groups="",resources=pods;configmaps,verbs=list
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=list
+
+// GetControlledPods returns the pods of the Jobs, StatefulSets and
+// Deployments controlled by the given owner, i.e. every pod of a benchmark
+// including the server side ones. The pods are listed with the selectors
+// of the workloads, so that the other pods of the namespace are not fetched.
+func (a *Access) GetControlledPods(owner metav1.Object) ([]corev1.Pod, error) {
+	workloads, err := a.listControlled(owner, &batchv1.JobList{}, &v1.StatefulSetList{}, &v1.DeploymentList{})
+	if err != nil {
+		return nil, err
+	}
+
+	controllers := map[types.UID]bool{}
+	var deployments []metav1.Object
+	for _, workload := range workloads {
+		controllers[workload.GetUID()] = true
+		if _, ok := workload.(*v1.Deployment); ok {
+			deployments = append(deployments, workload)
+		}
+	}
+	for _, deployment := range deployments {
+		replicaSets, err := a.listControlled(deployment, &v1.ReplicaSetList{})
+		if err != nil {
+			return nil, err
+		}
+		for _, replicaSet := range replicaSets {
+			controllers[replicaSet.GetUID()] = true
+		}
+	}
+
+	listed := map[types.UID]bool{}
+	var controlledPods []corev1.Pod
+	for _, workload := range workloads {
+		selector, err := podSelector(workload)
+		if err != nil {
+			return nil, err
+		} else if selector == nil {
+			continue
+		}
+		var pods corev1.PodList
+		if err := a.readerOf(&pods).List(context.TODO(), &pods, client.InNamespace(owner.GetNamespace()),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			ref := metav1.GetControllerOf(&pod)
+			if ref == nil || !controllers[ref.UID] || listed[pod.UID] {
+				continue
+			}
+			listed[pod.UID] = true
+			controlledPods = append(controlledPods, pod)
+		}
+	}
+	return controlledPods, nil
+}
+
+// podSelector returns the selector of the pods of the workload, or nil if
+// the workload has none. Jobs without a selector are matched by the
+// controller-uid label set by the job controller.
+func podSelector(workload metav1.Object) (labels.Selector, error) {
+	var selector *metav1.LabelSelector
+	switch typed := workload.(type) {
+	case *batchv1.Job:
+		if typed.Spec.Selector == nil {
+			return labels.SelectorFromSet(labels.Set{"controller-uid": string(typed.UID)}), nil
+		}
+		selector = typed.Spec.Selector
+	case *v1.StatefulSet:
+		selector = typed.Spec.Selector
+	case *v1.Deployment:
+		selector = typed.Spec.Selector
+	}
+	if selector == nil {
+		return nil, nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=delete
+
+// ListControlledObjects returns the Jobs, StatefulSets, Deployments,
+// Services, ConfigMaps and PersistentVolumeClaims controlled by the given owner
+func (a *Access) ListControlledObjects(owner metav1.Object) ([]metav1.Object, error) {
+	return a.listControlled(owner, &batchv1.JobList{}, &v1.StatefulSetList{}, &v1.DeploymentList{},
+		&corev1.ServiceList{}, &corev1.ConfigMapList{}, &corev1.PersistentVolumeClaimList{})
+}
+
+// listControlled returns the items of the given lists controlled by the
+// owner. The objects are listed with the reader returned by readerOf.
+func (a *Access) listControlled(owner metav1.Object, lists ...runtime.Object) ([]metav1.Object, error) {
+	var controlled []metav1.Object
+	for _, list := range lists {
+		if err := a.readerOf(list).List(context.TODO(), list, client.InNamespace(owner.GetNamespace())); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if object, ok := item.(metav1.Object); ok && isControlledBy(object, owner) {
+				controlled = append(controlled, object)
+			}
+		}
+	}
+	return controlled, nil
+}
+
+// readerOf returns the reader listing the given type. The workloads,
+// Services and PersistentVolumeClaims are owned by the benchmarks and
+// therefore watched, so they are listed from the cache of the manager.
+// The Pods, ConfigMaps and ReplicaSets are only looked up occasionally
+// and are listed from the API server.
+func (a *Access) readerOf(list runtime.Object) client.Reader {
+	switch list.(type) {
+	case *corev1.PodList, *corev1.ConfigMapList, *v1.ReplicaSetList:
+		return a.APIReader
+	}
+	return a.Client
+}
+
+// DeleteControlledObjects deletes the objects controlled by the given
+// owner which are selected by the filter. The pods of the deleted
+// workloads are deleted as well.
+func (a *Access) DeleteControlledObjects(ctx context.Context, owner metav1.Object,
+	selected func(metav1.Object) bool) error {
+	objects, err := a.ListControlledObjects(owner)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if !selected(object) {
+			continue
+		}
+		if err := a.DeleteObject(ctx, object, owner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsWorkload returns true for the objects running pods: Jobs,
+// StatefulSets and Deployments
+func IsWorkload(object metav1.Object) bool {
+	switch object.(type) {
+	case *batchv1.Job, *v1.StatefulSet, *v1.Deployment:
+		return true
+	}
+	return false
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=update
+
+// ScaleDownControlledObjects scales the Deployments and StatefulSets
+// controlled by the given owner to zero replicas, keeping the objects
+// (and the PersistentVolumeClaims of the StatefulSets) in place
+func (a *Access) ScaleDownControlledObjects(ctx context.Context, owner metav1.Object) error {
+	objects, err := a.ListControlledObjects(owner)
+	if err != nil {
+		return err
+	}
+	zero := int32(0)
+	for _, object := range objects {
+		var replicas **int32
+		switch workload := object.(type) {
+		case *v1.Deployment:
+			replicas = &workload.Spec.Replicas
+		case *v1.StatefulSet:
+			replicas = &workload.Spec.Replicas
+		default:
+			continue
+		}
+		if *replicas != nil && **replicas == 0 {
+			continue
+		}
+		*replicas = &zero
+		if err := a.Client.Update(ctx, object.(runtime.Object)); IgnoreNotFound(err) != nil {
+			return err
+		}
+		_ = a.RecordEventf(owner, corev1.EventTypeNormal, ScaledDown,
+			"Scaled down %v", object.GetSelfLink())
+	}
+	return nil
+}
+
+// isControlledBy returns true if the controller of object is owner
+func isControlledBy(object, owner metav1.Object) bool {
+	ref := metav1.GetControllerOf(object)
+	return ref != nil && ref.UID == owner.GetUID()
+}
+
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// maxPodLogLines limits the lines of the logs sent by the API server
+const maxPodLogLines = 1 << 20
+
+// GetPodLogs returns the last limit bytes of the logs of the given container
+// of the pod, as in TruncateLogs. The logs are streamed, so that the output
+// of a chatty container is never held in memory as a whole.
+func (a *Access) GetPodLogs(namespacedName types.NamespacedName, container string, limit int) (string, error) {
+	tailLines := int64(maxPodLogLines)
+	stream, err := a.Clientset.CoreV1().Pods(namespacedName.Namespace).GetLogs(
+		namespacedName.Name, &corev1.PodLogOptions{Container: container, TailLines: &tailLines}).Stream()
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	logs := tailWriter{limit: limit}
+	if _, err := io.Copy(&logs, stream); err != nil {
+		return "", err
+	}
+	return logs.String(), nil
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// CheckSecretKeyRef returns an error if the Secret or the key referred by the
+// selector does not exist in the given namespace. Nil selectors and missing
+// optional Secrets or keys are accepted.
+func (a *Access) CheckSecretKeyRef(namespace string, selector *corev1.SecretKeySelector) error {
+	if selector == nil {
+		return nil
+	}
+	optional := selector.Optional != nil && *selector.Optional
+
+	secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(selector.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) && optional {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to get Secret '%s': %v", selector.Name, err)
+	}
+
+	if _, ok := secret.Data[selector.Key]; !ok && !optional {
+		return fmt.Errorf("The key '%s' is missing from Secret '%s'", selector.Key, selector.Name)
+	}
+	return nil
+}
+
+// GetSecretValue returns the value of the key of the Secret referred by
+// the selector in the given namespace
+func (a *Access) GetSecretValue(namespace string, selector corev1.SecretKeySelector) (string, error) {
+	secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(selector.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("Unable to get Secret '%s': %v", selector.Name, err)
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("The key '%s' is missing from Secret '%s'", selector.Key, selector.Name)
+	}
+	return string(value), nil
+}
+
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list
 
 // IsEndpointReady returns true if the given endpoint is fully connected to at least one pod
@@ -23,10 +23,13 @@ import (
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	// batchv1 "k8s.io/api/batch/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
 )
@@ -49,6 +52,7 @@ var _ = Describe("Client", func() {
 			Clientset:     clientset,
 			Scheme:        scheme,
 			EventRecorder: NewEventRecorder(clientset, nil),
+			APIReader:     cl,
 		}
 		pod = &corev1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
@@ -125,3 +129,72 @@ var _ = Describe("Client", func() {
 		})
 	})
 })
+
+var _ = Describe("ListControlledObjects", func() {
+	It("should return only the objects controlled by the owner", func() {
+		const namespace = "fake-namespace"
+		owner := &perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake-fio", Namespace: namespace, UID: "cr-uid"},
+		}
+		isController := true
+		controlledBy := func(uid types.UID) []metav1.OwnerReference {
+			return []metav1.OwnerReference{{Name: "owner", UID: uid, Controller: &isController}}
+		}
+		fakeClient := fake.NewFakeClientWithScheme(k8sscheme.Scheme,
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name: "fio", Namespace: namespace, OwnerReferences: controlledBy(owner.UID)}},
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name: "other", Namespace: namespace, OwnerReferences: controlledBy("other-uid")}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: "fio", Namespace: namespace, OwnerReferences: controlledBy(owner.UID)}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "fio-x7rzt", Namespace: namespace}},
+		)
+		fakeAccess := &Access{Client: fakeClient, APIReader: fakeClient}
+
+		objects, err := fakeAccess.ListControlledObjects(owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(2))
+		Expect(objects[0]).To(BeAssignableToTypeOf(&batchv1.Job{}))
+		Expect(objects[0].GetName()).To(Equal("fio"))
+		Expect(objects[1]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+	})
+})
+
+var _ = Describe("GetControlledPods", func() {
+	It("should return the pods of the workloads controlled by the owner", func() {
+		const namespace = "fake-namespace"
+		owner := &perfv1alpha1.Fio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake-fio", Namespace: namespace, UID: "cr-uid"},
+		}
+		isController := true
+		controlledBy := func(uid types.UID) []metav1.OwnerReference {
+			return []metav1.OwnerReference{{Name: "owner", UID: uid, Controller: &isController}}
+		}
+		pod := func(name string, uid types.UID, labels map[string]string) *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: namespace, UID: types.UID(name),
+				Labels: labels, OwnerReferences: controlledBy(uid)}}
+		}
+		fakeClient := fake.NewFakeClientWithScheme(k8sscheme.Scheme,
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name: "fio", Namespace: namespace, UID: "job-uid", OwnerReferences: controlledBy(owner.UID)}},
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "server", Namespace: namespace, UID: "sts-uid", OwnerReferences: controlledBy(owner.UID)},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}}},
+			},
+			pod("fio-x7rzt", "job-uid", map[string]string{"controller-uid": "job-uid"}),
+			pod("server-0", "sts-uid", map[string]string{"app": "server"}),
+			pod("other-0", "other-uid", map[string]string{"app": "server"}),
+			pod("unrelated", "other-uid", nil),
+		)
+		fakeAccess := &Access{Client: fakeClient, APIReader: fakeClient}
+
+		pods, err := fakeAccess.GetControlledPods(owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods).To(HaveLen(2))
+		Expect(pods[0].Name).To(Equal("fio-x7rzt"))
+		Expect(pods[1].Name).To(Equal("server-0"))
+	})
+})
@@ -32,6 +32,36 @@ const (
 	Created = "Created"
 	// Deleted is an event provided via EventRecorder
 	Deleted = "Deleted"
+	// Failed is an event provided via EventRecorder
+	Failed = "Failed"
+	// ResultCollectionFailed is an event provided via EventRecorder
+	ResultCollectionFailed = "ResultCollectionFailed"
+	// Skipped is an event provided via EventRecorder
+	Skipped = "Skipped"
+	// BaselineCompared is an event provided via EventRecorder
+	BaselineCompared = "BaselineCompared"
+	// BaselineComparisonFailed is an event provided via EventRecorder
+	BaselineComparisonFailed = "BaselineComparisonFailed"
+	// ThresholdsViolated is an event provided via EventRecorder
+	ThresholdsViolated = "ThresholdsViolated"
+	// ResultsStored is an event provided via EventRecorder
+	ResultsStored = "ResultsStored"
+	// SinkFailed is an event provided via EventRecorder
+	SinkFailed = "SinkFailed"
+	// LogArchiveFailed is an event provided via EventRecorder
+	LogArchiveFailed = "LogArchiveFailed"
+	// TimedOut is an event provided via EventRecorder
+	TimedOut = "TimedOut"
+	// Rerun is an event provided via EventRecorder
+	Rerun = "Rerun"
+	// ScaledDown is an event provided via EventRecorder
+	ScaledDown = "ScaledDown"
+	// Cancelled is an event provided via EventRecorder
+	Cancelled = "Cancelled"
+	// Queued is an event provided via EventRecorder
+	Queued = "Queued"
+	// FingerprintFailed is an event provided via EventRecorder
+	FingerprintFailed = "FingerprintFailed"
 )
 
 // NewEventRecorder creates a new event recorder
@@ -83,14 +83,12 @@ func NewPerfJob(objectMeta metav1.ObjectMeta, app string, imageSpec perfv1alpha1
 							Resources:       podConfig.Resources,
 						},
 					},
-					ImagePullSecrets: []corev1.LocalObjectReference{
-						{Name: imageSpec.PullSecret},
-					},
-					RestartPolicy: corev1.RestartPolicyNever,
-					Affinity:      podConfig.PodScheduling.Affinity,
-					Tolerations:   podConfig.PodScheduling.Tolerations,
-					NodeSelector:  podConfig.PodScheduling.NodeSelector,
-					NodeName:      podConfig.PodScheduling.NodeName,
+					ImagePullSecrets: ImagePullSecrets(imageSpec),
+					RestartPolicy:    corev1.RestartPolicyNever,
+					Affinity:         podConfig.PodScheduling.Affinity,
+					Tolerations:      podConfig.PodScheduling.Tolerations,
+					NodeSelector:     podConfig.PodScheduling.NodeSelector,
+					NodeName:         podConfig.PodScheduling.NodeName,
 				},
 			},
 			BackoffLimit: &backoffLimit,
@@ -99,3 +97,69 @@ func NewPerfJob(objectMeta metav1.ObjectMeta, app string, imageSpec perfv1alpha1
 
 	return &job
 }
+
+// JobPhase is the lifecycle phase of a kubernetes job as seen by the benchmarks
+type JobPhase string
+
+const (
+	// JobRunning means that the job has neither succeeded nor failed yet
+	JobRunning JobPhase = "Running"
+	// JobSucceeded means that the job has completed successfully
+	JobSucceeded JobPhase = "Succeeded"
+	// JobFailed means that the job has failed and will not be retried
+	JobFailed JobPhase = "Failed"
+)
+
+// JobOutcome describes the state of a kubernetes job. In case of failure
+// the Reason and Message fields are populated from the job conditions.
+type JobOutcome struct {
+	Phase   JobPhase
+	Reason  string
+	Message string
+}
+
+// Finished returns true if the job has already succeeded or failed
+func (o JobOutcome) Finished() bool {
+	return o.Phase != JobRunning
+}
+
+// NewJobOutcome determines the outcome of the given job based on its
+// status conditions and completion time
+func NewJobOutcome(job *batchv1.Job) JobOutcome {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			return JobOutcome{
+				Phase:   JobFailed,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+		case batchv1.JobComplete:
+			return JobOutcome{Phase: JobSucceeded}
+		}
+	}
+
+	if job.Status.CompletionTime != nil {
+		return JobOutcome{Phase: JobSucceeded}
+	}
+
+	return JobOutcome{Phase: JobRunning}
+}
+
+// ImagePullSecrets returns the references to the pull secrets of the
+// image, without the empty and the duplicate ones
+func ImagePullSecrets(imageSpec perfv1alpha1.ImageSpec) []corev1.LocalObjectReference {
+	var pullSecrets []corev1.LocalObjectReference
+	seen := map[string]bool{}
+	for _, name := range append([]string{imageSpec.PullSecret}, imageSpec.PullSecrets...) {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+	return pullSecrets
+}
@@ -167,3 +167,71 @@ var _ = Describe("perf job", func() {
 		})
 	})
 })
+
+var _ = Describe("job outcome", func() {
+	var job *batchv1.Job
+
+	BeforeEach(func() {
+		job = &batchv1.Job{}
+	})
+
+	Context("without conditions and completion time", func() {
+		It("should be running", func() {
+			outcome := NewJobOutcome(job)
+			Expect(outcome.Phase).To(Equal(JobRunning))
+			Expect(outcome.Finished()).To(BeFalse())
+		})
+	})
+
+	Context("with Complete condition", func() {
+		It("should be succeeded", func() {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}
+			outcome := NewJobOutcome(job)
+			Expect(outcome.Phase).To(Equal(JobSucceeded))
+			Expect(outcome.Finished()).To(BeTrue())
+		})
+	})
+
+	Context("with completion time only", func() {
+		It("should be succeeded", func() {
+			completionTime := metav1.Now()
+			job.Status.CompletionTime = &completionTime
+			Expect(NewJobOutcome(job).Phase).To(Equal(JobSucceeded))
+		})
+	})
+
+	Context("with Failed condition", func() {
+		BeforeEach(func() {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "BackoffLimitExceeded",
+					Message: "Job has reached the specified backoff limit",
+				},
+			}
+		})
+
+		It("should be failed", func() {
+			outcome := NewJobOutcome(job)
+			Expect(outcome.Phase).To(Equal(JobFailed))
+			Expect(outcome.Finished()).To(BeTrue())
+		})
+		It("should provide the failure reason", func() {
+			outcome := NewJobOutcome(job)
+			Expect(outcome.Reason).To(Equal("BackoffLimitExceeded"))
+			Expect(outcome.Message).To(Equal("Job has reached the specified backoff limit"))
+		})
+	})
+
+	Context("with Failed condition not being true", func() {
+		It("should be running", func() {
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionFalse},
+			}
+			Expect(NewJobOutcome(job).Phase).To(Equal(JobRunning))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+)
+
+// truncatedNote precedes the logs whose beginning has been dropped
+const truncatedNote = "[%d bytes truncated]\n"
+
+// TruncateLogs keeps the last limit bytes of the logs, as the summaries of
+// the benchmarks are at the end of their output. The dropped beginning is
+// replaced by a note, which accounts for the bytes dropped by earlier
+// truncations as well. The number of dropped bytes is returned.
+func TruncateLogs(logs string, limit int) (truncated string, dropped int) {
+	if _, err := fmt.Sscanf(logs, truncatedNote, &dropped); err == nil {
+		logs = logs[strings.Index(logs, "\n")+1:]
+	} else {
+		dropped = 0
+	}
+
+	if len(logs) > limit {
+		dropped += len(logs) - limit
+		logs = logs[len(logs)-limit:]
+	}
+	if dropped == 0 {
+		return logs, 0
+	}
+	return fmt.Sprintf(truncatedNote, dropped) + logs, dropped
+}
+
+// tailWriter keeps the last limit bytes written to it while holding at
+// most twice as much in memory
+type tailWriter struct {
+	limit   int
+	dropped int
+	data    []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	if len(w.data) > 2*w.limit {
+		drop := len(w.data) - w.limit
+		w.dropped += drop
+		w.data = append(make([]byte, 0, 2*w.limit), w.data[drop:]...)
+	}
+	return len(p), nil
+}
+
+// String returns the kept logs preceded by the note of TruncateLogs
+func (w *tailWriter) String() string {
+	data, dropped := w.data, w.dropped
+	if len(data) > w.limit {
+		dropped += len(data) - w.limit
+		data = data[len(data)-w.limit:]
+	}
+	if dropped == 0 {
+		return string(data)
+	}
+	return fmt.Sprintf(truncatedNote, dropped) + string(data)
+}
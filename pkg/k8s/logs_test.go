@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pod logs", func() {
+	It("keeps short logs intact", func() {
+		writer := tailWriter{limit: 10}
+		_, _ = writer.Write([]byte("short"))
+		Expect(writer.String()).To(Equal("short"))
+	})
+
+	It("keeps the tail of long logs", func() {
+		writer := tailWriter{limit: 4}
+		for _, chunk := range []string{"abc", "defgh", "ij", "klmnopqrstu"} {
+			_, _ = writer.Write([]byte(chunk))
+		}
+		Expect(writer.String()).To(Equal("[17 bytes truncated]\nrstu"))
+		Expect(len(writer.data)).To(BeNumerically("<=", 2*writer.limit))
+	})
+
+	It("accounts for the bytes truncated earlier", func() {
+		logs, dropped := TruncateLogs("[17 bytes truncated]\nrstu", 2)
+		Expect(logs).To(Equal("[19 bytes truncated]\ntu"))
+		Expect(dropped).To(Equal(19))
+
+		logs, dropped = TruncateLogs("[17 bytes truncated]\nrstu", 4)
+		Expect(logs).To(Equal("[17 bytes truncated]\nrstu"))
+		Expect(dropped).To(Equal(17))
+	})
+
+	It("leaves the logs within the limit untouched", func() {
+		logs, dropped := TruncateLogs("[x] done", 100)
+		Expect(logs).To(Equal("[x] done"))
+		Expect(dropped).To(BeZero())
+	})
+})
@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// seccompPodAnnotation sets the seccomp profile of the pod on clusters
+// which do not have the seccompProfile field in the security context yet
+const seccompPodAnnotation = "seccomp.security.alpha.kubernetes.io/pod"
+
+// ApplyPodConfiguration applies the security, runtime, DNS, environment,
+// volume and topology spread settings of the pod configuration to the pod
+// template. The environment variables and the volume mounts are appended
+// to the init and main containers, after the ones set by the benchmark,
+// so they take precedence. The container security context only fills the
+// attributes the benchmark has not set. The rest of the scheduling, the
+// labels, annotations and resources are set by the builders of the
+// benchmark objects.
+func ApplyPodConfiguration(template *corev1.PodTemplateSpec, podConfig perfv1alpha1.PodConfigurationSpec) {
+	spec := &template.Spec
+	if podConfig.ServiceAccountName != "" {
+		spec.ServiceAccountName = podConfig.ServiceAccountName
+	}
+	if podConfig.SecurityContext != nil {
+		spec.SecurityContext = podConfig.SecurityContext.DeepCopy()
+	}
+	if podConfig.PriorityClassName != "" {
+		spec.PriorityClassName = podConfig.PriorityClassName
+	}
+	if podConfig.RuntimeClassName != nil {
+		runtimeClassName := *podConfig.RuntimeClassName
+		spec.RuntimeClassName = &runtimeClassName
+	}
+	spec.HostNetwork = spec.HostNetwork || podConfig.HostNetwork
+	spec.HostPID = spec.HostPID || podConfig.HostPID
+	if podConfig.DNSPolicy != "" {
+		spec.DNSPolicy = podConfig.DNSPolicy
+	}
+	if podConfig.DNSConfig != nil {
+		spec.DNSConfig = podConfig.DNSConfig.DeepCopy()
+	}
+	spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints,
+		podConfig.PodScheduling.TopologySpreadConstraints...)
+	spec.Volumes = append(spec.Volumes, podConfig.Volumes...)
+
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			container.Env = append(container.Env, podConfig.Env...)
+			container.EnvFrom = append(container.EnvFrom, podConfig.EnvFrom...)
+			container.VolumeMounts = append(container.VolumeMounts, podConfig.VolumeMounts...)
+			if podConfig.ContainerSecurityContext != nil {
+				container.SecurityContext = mergeSecurityContext(
+					container.SecurityContext, podConfig.ContainerSecurityContext)
+			}
+		}
+	}
+
+	if podConfig.SecurityProfile == perfv1alpha1.SecurityProfileRestricted {
+		applyRestrictedProfile(template)
+	}
+}
+
+// mergeSecurityContext returns the security context of the container with
+// its unset attributes taken from defaults
+func mergeSecurityContext(securityContext, defaults *corev1.SecurityContext) *corev1.SecurityContext {
+	merged := defaults.DeepCopy()
+	if securityContext == nil {
+		return merged
+	}
+
+	result := securityContext.DeepCopy()
+	if result.Capabilities == nil {
+		result.Capabilities = merged.Capabilities
+	}
+	if result.Privileged == nil {
+		result.Privileged = merged.Privileged
+	}
+	if result.SELinuxOptions == nil {
+		result.SELinuxOptions = merged.SELinuxOptions
+	}
+	if result.RunAsUser == nil {
+		result.RunAsUser = merged.RunAsUser
+	}
+	if result.RunAsGroup == nil {
+		result.RunAsGroup = merged.RunAsGroup
+	}
+	if result.RunAsNonRoot == nil {
+		result.RunAsNonRoot = merged.RunAsNonRoot
+	}
+	if result.ReadOnlyRootFilesystem == nil {
+		result.ReadOnlyRootFilesystem = merged.ReadOnlyRootFilesystem
+	}
+	if result.AllowPrivilegeEscalation == nil {
+		result.AllowPrivilegeEscalation = merged.AllowPrivilegeEscalation
+	}
+	if result.ProcMount == nil {
+		result.ProcMount = merged.ProcMount
+	}
+	return result
+}
+
+// applyRestrictedProfile fills the security attributes of the pod and of
+// all of its containers which are not set explicitly with the values
+// required by the restricted Pod Security Standard
+func applyRestrictedProfile(template *corev1.PodTemplateSpec) {
+	if _, ok := template.Annotations[seccompPodAnnotation]; !ok {
+		// The annotations of the template may be shared with the owner object
+		annotations := map[string]string{seccompPodAnnotation: "runtime/default"}
+		for key, value := range template.Annotations {
+			annotations[key] = value
+		}
+		template.Annotations = annotations
+	}
+
+	spec := &template.Spec
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	if spec.SecurityContext.RunAsNonRoot == nil {
+		spec.SecurityContext.RunAsNonRoot = boolPtr(true)
+	}
+
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			container := &containers[i]
+			if container.SecurityContext != nil && container.SecurityContext.Privileged != nil &&
+				*container.SecurityContext.Privileged {
+				// Privileged containers cannot comply with the profile
+				continue
+			}
+			if container.SecurityContext == nil {
+				container.SecurityContext = &corev1.SecurityContext{}
+			}
+			securityContext := container.SecurityContext
+			if securityContext.AllowPrivilegeEscalation == nil {
+				securityContext.AllowPrivilegeEscalation = boolPtr(false)
+			}
+			if securityContext.Capabilities == nil {
+				securityContext.Capabilities = &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				}
+			}
+		}
+	}
+}
+
+// boolPtr returns a pointer to the value
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("pod configuration", func() {
+	var template corev1.PodTemplateSpec
+	var podConfig perfv1alpha1.PodConfigurationSpec
+
+	BeforeEach(func() {
+		template = corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{
+						Name: "init",
+						Env:  []corev1.EnvVar{{Name: "INIT", Value: "1"}},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name: "main",
+						SecurityContext: &corev1.SecurityContext{
+							Privileged: boolPtr(true),
+						},
+					},
+				},
+				Volumes: []corev1.Volume{{Name: "benchmark"}},
+			},
+		}
+		spreadConstraint := corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+		}
+		runAsUser := int64(1000)
+		podConfig = perfv1alpha1.PodConfigurationSpec{
+			ServiceAccountName: "benchmarks",
+			PodScheduling: perfv1alpha1.PodSchedulingSpec{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{spreadConstraint},
+			},
+			Env:          []corev1.EnvVar{{Name: "EXTRA", Value: "2"}},
+			Volumes:      []corev1.Volume{{Name: "extra"}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "extra", MountPath: "/extra"}},
+			ContainerSecurityContext: &corev1.SecurityContext{
+				Privileged: boolPtr(false),
+				RunAsUser:  &runAsUser,
+			},
+		}
+	})
+
+	Context("when applied", func() {
+		BeforeEach(func() {
+			ApplyPodConfiguration(&template, podConfig)
+		})
+
+		It("should set the pod level settings", func() {
+			Expect(template.Spec.ServiceAccountName).To(Equal("benchmarks"))
+			Expect(template.Spec.Volumes).To(Equal([]corev1.Volume{{Name: "benchmark"}, {Name: "extra"}}))
+		})
+
+		It("should set the topology spread constraints", func() {
+			Expect(template.Spec.TopologySpreadConstraints).To(
+				Equal(podConfig.PodScheduling.TopologySpreadConstraints))
+		})
+
+		It("should append the env and the mounts to the init containers too", func() {
+			for _, container := range append(template.Spec.InitContainers, template.Spec.Containers...) {
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "EXTRA", Value: "2"}))
+				Expect(container.VolumeMounts).To(
+					ContainElement(corev1.VolumeMount{Name: "extra", MountPath: "/extra"}))
+			}
+			Expect(template.Spec.InitContainers[0].Env[0].Name).To(Equal("INIT"))
+		})
+
+		It("should only fill the unset attributes of the container security context", func() {
+			securityContext := template.Spec.Containers[0].SecurityContext
+			Expect(*securityContext.Privileged).To(BeTrue())
+			Expect(*securityContext.RunAsUser).To(Equal(int64(1000)))
+
+			securityContext = template.Spec.InitContainers[0].SecurityContext
+			Expect(*securityContext.Privileged).To(BeFalse())
+			Expect(*securityContext.RunAsUser).To(Equal(int64(1000)))
+		})
+
+		It("should not modify the pod configuration", func() {
+			Expect(*podConfig.ContainerSecurityContext.Privileged).To(BeFalse())
+		})
+	})
+
+	Context("with the restricted profile", func() {
+		BeforeEach(func() {
+			podConfig.ContainerSecurityContext = nil
+			podConfig.SecurityProfile = perfv1alpha1.SecurityProfileRestricted
+			ApplyPodConfiguration(&template, podConfig)
+		})
+
+		It("should restrict the pod and the unprivileged containers", func() {
+			Expect(template.Annotations).To(HaveKeyWithValue(seccompPodAnnotation, "runtime/default"))
+			Expect(*template.Spec.SecurityContext.RunAsNonRoot).To(BeTrue())
+
+			securityContext := template.Spec.InitContainers[0].SecurityContext
+			Expect(*securityContext.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(securityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+		})
+
+		It("should leave the privileged containers alone", func() {
+			securityContext := template.Spec.Containers[0].SecurityContext
+			Expect(securityContext.AllowPrivilegeEscalation).To(BeNil())
+			Expect(securityContext.Capabilities).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MinRequeueDelay is the shortest delay used by RequeueDelay
+	MinRequeueDelay = time.Second
+	// MaxRequeueDelay is the longest delay used by RequeueDelay
+	MaxRequeueDelay = 30 * time.Second
+)
+
+// RequeueDelay returns how long the reconciler should wait before polling
+// the state of an object which cannot be watched (e.g. Endpoints). The
+// delay grows with the time spent waiting since the given start time, so
+// short waits are noticed quickly while long waits do not load the API
+// server.
+func RequeueDelay(since *metav1.Time) time.Duration {
+	if since == nil {
+		return MinRequeueDelay
+	}
+
+	delay := time.Since(since.Time) / 10
+	if delay < MinRequeueDelay {
+		return MinRequeueDelay
+	}
+	if delay > MaxRequeueDelay {
+		return MaxRequeueDelay
+	}
+	return delay
+}
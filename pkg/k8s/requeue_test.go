@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("requeue delay", func() {
+	It("uses the minimum delay without start time", func() {
+		Expect(RequeueDelay(nil)).To(Equal(MinRequeueDelay))
+	})
+
+	It("uses the minimum delay right after the start", func() {
+		start := metav1.Now()
+		Expect(RequeueDelay(&start)).To(Equal(MinRequeueDelay))
+	})
+
+	It("grows with the elapsed time", func() {
+		start := metav1.NewTime(time.Now().Add(-100 * time.Second))
+		delay := RequeueDelay(&start)
+		Expect(delay).To(BeNumerically(">", MinRequeueDelay))
+		Expect(delay).To(BeNumerically("<", MaxRequeueDelay))
+	})
+
+	It("is capped at the maximum delay", func() {
+		start := metav1.NewTime(time.Now().Add(-time.Hour))
+		Expect(RequeueDelay(&start)).To(Equal(MaxRequeueDelay))
+	})
+})
@@ -0,0 +1,203 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// DefaultTolerance is the allowed relative difference from the baseline
+// in percent when the baseline does not specify it
+const DefaultTolerance = 5.0
+
+// runSuffix matches the run suffix of the job names (see RunName), followed
+// by the node index of the fanned out jobs
+var runSuffix = regexp.MustCompile(`-run[0-9]+(-[0-9]+)?$`)
+
+// Compare compares the metrics with the metrics of the baseline. Only the
+// metrics present in both, with the same name, labels and job, are
+// compared. The job of the metrics is expected to be its role (see
+// WithJobRoles).
+func Compare(baseline *perfv1alpha1.BenchmarkBaseline, baselineMetrics,
+	metrics []perfv1alpha1.BenchmarkMetric) (*perfv1alpha1.BaselineComparison, error) {
+	defaultTolerance := DefaultTolerance
+	if baseline.Spec.Tolerance != "" {
+		var err error
+		if defaultTolerance, err = strconv.ParseFloat(baseline.Spec.Tolerance, 64); err != nil {
+			return nil, fmt.Errorf("invalid tolerance %q: %v", baseline.Spec.Tolerance, err)
+		}
+	}
+
+	comparison := &perfv1alpha1.BaselineComparison{
+		Name:    baseline.Name,
+		Verdict: perfv1alpha1.BaselinePass,
+	}
+	for _, metric := range metrics {
+		reference := findBaselineMetric(baselineMetrics, metric)
+		if reference == nil {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of metric %s: %v", metric.Name, err)
+		}
+		referenceValue, err := strconv.ParseFloat(reference.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid baseline value of metric %s: %v", metric.Name, err)
+		}
+
+		tolerance, direction := defaultTolerance, defaultDirection(metric.Unit)
+		if override := findTolerance(baseline.Spec.Tolerances, metric); override != nil {
+			if override.Percent != "" {
+				if tolerance, err = strconv.ParseFloat(override.Percent, 64); err != nil {
+					return nil, fmt.Errorf("invalid tolerance %q of metric %s: %v",
+						override.Percent, metric.Name, err)
+				}
+			}
+			if override.Direction != "" {
+				direction = override.Direction
+			}
+		}
+
+		metricComparison := perfv1alpha1.MetricComparison{
+			Name:     metric.Name,
+			Labels:   metric.Labels,
+			Job:      metric.Job,
+			Baseline: reference.Value,
+			Value:    metric.Value,
+			Verdict:  perfv1alpha1.BaselinePass,
+		}
+		if referenceValue != 0 {
+			delta := (value - referenceValue) / math.Abs(referenceValue) * 100
+			metricComparison.Delta = strconv.FormatFloat(delta, 'f', 2, 64)
+			metricComparison.Verdict = verdict(delta, tolerance, direction)
+		}
+		comparison.Metrics = append(comparison.Metrics, metricComparison)
+
+		switch metricComparison.Verdict {
+		case perfv1alpha1.BaselineRegressed:
+			comparison.Verdict = perfv1alpha1.BaselineRegressed
+		case perfv1alpha1.BaselineImproved:
+			if comparison.Verdict == perfv1alpha1.BaselinePass {
+				comparison.Verdict = perfv1alpha1.BaselineImproved
+			}
+		}
+	}
+
+	return comparison, nil
+}
+
+// verdict judges the relative difference from the baseline in percent.
+// Metrics without a direction can not regress nor improve.
+func verdict(delta, tolerance float64, direction perfv1alpha1.MetricDirection) perfv1alpha1.BaselineVerdict {
+	switch direction {
+	case perfv1alpha1.HigherIsBetter:
+	case perfv1alpha1.LowerIsBetter:
+		delta = -delta
+	default:
+		return perfv1alpha1.BaselinePass
+	}
+
+	if delta < -tolerance {
+		return perfv1alpha1.BaselineRegressed
+	} else if delta > tolerance {
+		return perfv1alpha1.BaselineImproved
+	}
+	return perfv1alpha1.BaselinePass
+}
+
+// defaultDirection derives the direction of a metric from its unit:
+// durations and ratios (e.g. lost datagrams) are better lower, throughputs
+// are better higher. Other metrics (e.g. counts) have no direction.
+func defaultDirection(unit string) perfv1alpha1.MetricDirection {
+	switch unit {
+	case UnitSeconds, UnitPercent:
+		return perfv1alpha1.LowerIsBetter
+	case UnitBytesPerSecond, UnitBitsPerSecond, UnitOperationsPerSecond:
+		return perfv1alpha1.HigherIsBetter
+	}
+	return ""
+}
+
+// JobRole returns the role of the job of the benchmark CR with the given
+// name: the name of the job without the name of the CR and the run suffix,
+// e.g. `test-consumer` for the job `kafka-sample-test-consumer-run2` of
+// the CR `kafka-sample`. The role of the job named after the CR is empty.
+func JobRole(job, benchmark string) string {
+	role := runSuffix.ReplaceAllString(strings.TrimPrefix(job, benchmark), "$1")
+	return strings.TrimPrefix(role, "-")
+}
+
+// WithJobRoles returns a copy of the metrics of the benchmark CR with the
+// given name, with their job replaced by its role, so that they can be
+// compared with the metrics of another benchmark CR or run
+func WithJobRoles(metrics []perfv1alpha1.BenchmarkMetric, benchmark string) []perfv1alpha1.BenchmarkMetric {
+	roles := make([]perfv1alpha1.BenchmarkMetric, len(metrics))
+	for i := range metrics {
+		metrics[i].DeepCopyInto(&roles[i])
+		roles[i].Job = JobRole(metrics[i].Job, benchmark)
+	}
+	return roles
+}
+
+// findBaselineMetric returns the metric with the same name, labels and job or nil
+func findBaselineMetric(metrics []perfv1alpha1.BenchmarkMetric, metric perfv1alpha1.BenchmarkMetric) *perfv1alpha1.BenchmarkMetric {
+	for i := range metrics {
+		if metrics[i].Name == metric.Name && metrics[i].Job == metric.Job &&
+			sameLabels(metrics[i].Labels, metric.Labels) {
+			return &metrics[i]
+		}
+	}
+	return nil
+}
+
+// findTolerance returns the first tolerance matching the metric or nil
+func findTolerance(tolerances []perfv1alpha1.MetricTolerance, metric perfv1alpha1.BenchmarkMetric) *perfv1alpha1.MetricTolerance {
+	for i := range tolerances {
+		if tolerances[i].Metric != metric.Name {
+			continue
+		}
+		matches := true
+		for key, value := range tolerances[i].Labels {
+			if metricValue, ok := metric.Labels[key]; !ok || metricValue != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return &tolerances[i]
+		}
+	}
+	return nil
+}
+
+// sameLabels tells whether the label sets are equal, treating nil and
+// empty label sets as equal
+func sameLabels(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
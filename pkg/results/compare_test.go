@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Baseline comparison", func() {
+	var baseline perfv1alpha1.BenchmarkBaseline
+	var baselineMetrics []perfv1alpha1.BenchmarkMetric
+
+	BeforeEach(func() {
+		baseline = perfv1alpha1.BenchmarkBaseline{}
+		baseline.Name = "storage"
+		baselineMetrics = []perfv1alpha1.BenchmarkMetric{
+			{Name: "iops", Value: "1000", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "read"}},
+			{Name: "iops", Value: "500", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "write"}},
+			{Name: "latency_seconds", Value: "0.002", Unit: UnitSeconds},
+			{Name: "operations", Value: "100"},
+		}
+	})
+
+	It("should pass within the tolerance", func() {
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "iops", Value: "960", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "read"}},
+			{Name: "latency_seconds", Value: "0.0021", Unit: UnitSeconds},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Name).To(Equal("storage"))
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselinePass))
+		Expect(comparison.Metrics).To(HaveLen(2))
+		Expect(comparison.Metrics[0].Delta).To(Equal("-4.00"))
+		Expect(comparison.Metrics[0].Baseline).To(Equal("1000"))
+		Expect(comparison.Metrics[1].Delta).To(Equal("5.00"))
+	})
+
+	It("should regress when a throughput gets lower", func() {
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "iops", Value: "800", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "read"}},
+			{Name: "iops", Value: "600", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "write"}},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselineRegressed))
+		Expect(comparison.Metrics[0].Verdict).To(Equal(perfv1alpha1.BaselineRegressed))
+		Expect(comparison.Metrics[0].Delta).To(Equal("-20.00"))
+		Expect(comparison.Metrics[1].Verdict).To(Equal(perfv1alpha1.BaselineImproved))
+	})
+
+	It("should regress when a duration gets higher", func() {
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "latency_seconds", Value: "0.003", Unit: UnitSeconds},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselineRegressed))
+	})
+
+	It("should improve when a duration gets lower", func() {
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "latency_seconds", Value: "0.001", Unit: UnitSeconds},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselineImproved))
+	})
+
+	It("should not judge metrics without direction", func() {
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "operations", Value: "50"},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselinePass))
+		Expect(comparison.Metrics[0].Delta).To(Equal("-50.00"))
+	})
+
+	It("should apply the tolerances of the baseline", func() {
+		baseline.Spec.Tolerance = "25"
+		baseline.Spec.Tolerances = []perfv1alpha1.MetricTolerance{
+			{Metric: "iops", Labels: map[string]string{"rw": "write"}, Percent: "1"},
+			{Metric: "operations", Direction: perfv1alpha1.HigherIsBetter},
+		}
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "iops", Value: "800", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "read"}},
+			{Name: "iops", Value: "490", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "write"}},
+			{Name: "operations", Value: "130"},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Metrics[0].Verdict).To(Equal(perfv1alpha1.BaselinePass))
+		Expect(comparison.Metrics[1].Verdict).To(Equal(perfv1alpha1.BaselineRegressed))
+		Expect(comparison.Metrics[2].Verdict).To(Equal(perfv1alpha1.BaselineImproved))
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselineRegressed))
+	})
+
+	It("should only compare metrics with the same labels", func() {
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "iops", Value: "100", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "randread"}},
+			{Name: "bits_per_second", Value: "100", Unit: UnitBitsPerSecond},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Metrics).To(BeEmpty())
+		Expect(comparison.Verdict).To(Equal(perfv1alpha1.BaselinePass))
+	})
+
+	It("should compare the metrics of every job with the same job of the baseline", func() {
+		kafkaMetrics := func(producer, consumer, crName, suffix string) []perfv1alpha1.BenchmarkMetric {
+			return []perfv1alpha1.BenchmarkMetric{
+				{Name: "records_per_second", Value: producer, Unit: UnitOperationsPerSecond,
+					Job: crName + "-test-producer" + suffix},
+				{Name: "records_per_second", Value: consumer, Unit: UnitOperationsPerSecond,
+					Job: crName + "-test-consumer" + suffix},
+			}
+		}
+		reference := WithJobRoles(kafkaMetrics("1000", "2000", "kafka-reference", ""), "kafka-reference")
+		comparison, err := Compare(&baseline, reference,
+			WithJobRoles(kafkaMetrics("1000", "1000", "kafka-nightly", "-run3"), "kafka-nightly"))
+		Expect(err).To(BeNil())
+		Expect(comparison.Metrics).To(HaveLen(2))
+		Expect(comparison.Metrics[0].Job).To(Equal("test-producer"))
+		Expect(comparison.Metrics[0].Verdict).To(Equal(perfv1alpha1.BaselinePass))
+		Expect(comparison.Metrics[1].Job).To(Equal("test-consumer"))
+		Expect(comparison.Metrics[1].Baseline).To(Equal("2000"))
+		Expect(comparison.Metrics[1].Verdict).To(Equal(perfv1alpha1.BaselineRegressed))
+	})
+
+	It("should derive the role of the jobs from their name", func() {
+		Expect(JobRole("fio-sample", "fio-sample")).To(BeEmpty())
+		Expect(JobRole("fio-sample-run2", "fio-sample")).To(BeEmpty())
+		Expect(JobRole("fio-sample-run2-1", "fio-sample")).To(Equal("1"))
+		Expect(JobRole("kafka-sample-test-consumer-run12", "kafka-sample")).To(Equal("test-consumer"))
+		Expect(JobRole("sweep-run2-run3", "sweep-run2")).To(BeEmpty())
+	})
+
+	It("should leave the jobs of the given metrics unchanged", func() {
+		metrics := []perfv1alpha1.BenchmarkMetric{{Name: "iops", Job: "fio-sample-run2"}}
+		Expect(WithJobRoles(metrics, "fio-sample")[0].Job).To(BeEmpty())
+		Expect(metrics[0].Job).To(Equal("fio-sample-run2"))
+	})
+
+	It("should not compute the delta from zero", func() {
+		baselineMetrics[0].Value = "0"
+		comparison, err := Compare(&baseline, baselineMetrics, []perfv1alpha1.BenchmarkMetric{
+			{Name: "iops", Value: "100", Unit: UnitOperationsPerSecond, Labels: map[string]string{"rw": "read"}},
+		})
+		Expect(err).To(BeNil())
+		Expect(comparison.Metrics[0].Delta).To(BeEmpty())
+		Expect(comparison.Metrics[0].Verdict).To(Equal(perfv1alpha1.BaselinePass))
+	})
+
+	It("should reject invalid tolerances", func() {
+		baseline.Spec.Tolerance = "five"
+		_, err := Compare(&baseline, baselineMetrics, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
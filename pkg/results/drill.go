@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	drillCountRegexp      = regexp.MustCompile(`^(Total|Successful|Failed) requests\s+(\d+)`)
+	drillRateRegexp       = regexp.MustCompile(`^Requests per second\s+([\d.]+)`)
+	drillLatencyRegexp    = regexp.MustCompile(`^(Median|Average) time per request\s+([\d.]+)\s*(\w+)`)
+	drillPercentileRegexp = regexp.MustCompile(`^([\d.]+)'th percentile\s+([\d.]+)\s*(\w+)`)
+)
+
+// ParseDrill parses the statistics printed by drill. The statistics are
+// only printed when drill is executed with the --stats option.
+func ParseDrill(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	rateFound := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := drillCountRegexp.FindStringSubmatch(line); match != nil {
+			count, err := strconv.ParseFloat(match[2], 64)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric(strings.ToLower(match[1])+"_requests", count, "", nil))
+			continue
+		}
+		if match := drillRateRegexp.FindStringSubmatch(line); match != nil {
+			rate, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("requests_per_second", rate, UnitOperationsPerSecond, nil))
+			rateFound = true
+			continue
+		}
+		if match := drillLatencyRegexp.FindStringSubmatch(line); match != nil {
+			latency, err := parseDuration(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			name := "latency_median_seconds"
+			if match[1] == "Average" {
+				name = "latency_avg_seconds"
+			}
+			metrics = append(metrics, newMetric(name, latency, UnitSeconds, nil))
+			continue
+		}
+		if match := drillPercentileRegexp.FindStringSubmatch(line); match != nil {
+			latency, err := parseDuration(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("latency_seconds", latency, UnitSeconds,
+				map[string]string{"percentile": match[1]}))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !rateFound {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const drillOutput = `Fetch index                http://nginx/            200 OK 2ms
+
+Concurrency Level         4
+Time taken for tests      0.8 seconds
+Total requests            1000
+Successful requests       998
+Failed requests           2
+Requests per second       1258.42 [#/sec]
+Median time per request   7ms
+Average time per request  8ms
+Sample standard deviation 3ms
+99.0'th percentile        16ms
+99.5'th percentile        18ms
+`
+
+var _ = Describe("drill parser", func() {
+	It("parses the statistics", func() {
+		metrics, err := ParseDrill(drillOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "requests_per_second", nil)).To(Equal("1258.42"))
+		Expect(findMetric(metrics, "failed_requests", nil)).To(Equal("2"))
+		Expect(findMetric(metrics, "latency_median_seconds", nil)).To(Equal("0.007"))
+		Expect(findMetric(metrics, "latency_avg_seconds", nil)).To(Equal("0.008"))
+		Expect(findMetric(metrics, "latency_seconds", map[string]string{"percentile": "99.5"})).To(Equal("0.018"))
+	})
+
+	It("fails when drill was executed without --stats", func() {
+		_, err := ParseDrill("Fetch index    http://nginx/    200 OK 2ms\n")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
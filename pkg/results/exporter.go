@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// DurationMetric is the name of the gauge of the benchmark durations
+const DurationMetric = "kubestone_benchmark_duration_seconds"
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// sample is a single value of a gauge
+type sample struct {
+	name   string
+	help   string
+	labels map[string]string
+	value  float64
+}
+
+// Exporter exposes the results of the finished benchmarks as Prometheus
+// gauges, e.g. kubestone_fio_iops{cr="fio-sample",namespace="kubestone",job="fio-sample",rw="read"},
+// where job is the Kubernetes job whose output the metric was parsed from.
+// The labels of the metrics which clash with these are prefixed with the
+// kind of the benchmark, e.g. the job label of fio becomes fio_job.
+// The metrics of the benchmarks have different label sets, so the
+// Exporter is an unchecked collector: it does not describe its metrics
+// in advance.
+type Exporter struct {
+	mutex   sync.RWMutex
+	samples map[string][]sample
+}
+
+// NewExporter creates an Exporter without results
+func NewExporter() *Exporter {
+	return &Exporter{samples: map[string][]sample{}}
+}
+
+// SetResults replaces the gauges of the benchmark CR of the given kind
+// with its duration and the metrics of its results
+func (e *Exporter) SetResults(kind string, cr types.NamespacedName, status *perfv1alpha1.BenchmarkStatus) {
+	crLabels := map[string]string{"cr": cr.Name, "namespace": cr.Namespace}
+
+	var samples []sample
+	if status.StartTime != nil && status.CompletionTime != nil {
+		samples = append(samples, sample{
+			name: DurationMetric,
+			help: "Duration of the finished benchmarks",
+			labels: mergeLabels(crLabels, map[string]string{
+				"kind":  kind,
+				"phase": string(status.Phase),
+			}),
+			value: status.CompletionTime.Sub(status.StartTime.Time).Seconds(),
+		})
+	}
+
+	if status.Results != nil {
+		for _, metric := range status.Results.Metrics {
+			value, err := strconv.ParseFloat(metric.Value, 64)
+			if err != nil {
+				continue
+			}
+			name := sanitizeName(fmt.Sprintf("kubestone_%s_%s", strings.ToLower(kind), metric.Name))
+			help := fmt.Sprintf("%s result of the %s benchmarks", metric.Name, kind)
+			if metric.Unit != "" {
+				help += " in " + metric.Unit
+			}
+			identity := mergeLabels(crLabels, nil)
+			if metric.Job != "" {
+				identity["job"] = metric.Job
+			}
+			labels := map[string]string{}
+			for name, value := range metric.Labels {
+				if _, clashes := identity[sanitizeName(name)]; clashes {
+					name = strings.ToLower(kind) + "_" + name
+				}
+				labels[name] = value
+			}
+			samples = append(samples, sample{
+				name:   name,
+				help:   help,
+				labels: mergeLabels(labels, identity),
+				value:  value,
+			})
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.samples[exporterKey(kind, cr)] = samples
+}
+
+// DeleteResults removes the gauges of the benchmark CR of the given kind
+func (e *Exporter) DeleteResults(kind string, cr types.NamespacedName) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	delete(e.samples, exporterKey(kind, cr))
+}
+
+// Describe implements prometheus.Collector. It does not send any
+// descriptor, which makes the Exporter an unchecked collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+}
+
+// Collect implements prometheus.Collector
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	// The same metric can not be collected twice, it would fail the scrape
+	collected := map[string]bool{}
+	for _, samples := range e.samples {
+		for _, s := range samples {
+			names := make([]string, 0, len(s.labels))
+			for name := range s.labels {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			values := make([]string, 0, len(names))
+			for _, name := range names {
+				values = append(values, s.labels[name])
+			}
+
+			id := s.name + "{" + strings.Join(names, ",") + "}" + strings.Join(values, "\xff")
+			if collected[id] {
+				continue
+			}
+			collected[id] = true
+
+			metric, err := prometheus.NewConstMetric(prometheus.NewDesc(s.name, s.help, names, nil),
+				prometheus.GaugeValue, s.value, values...)
+			if err != nil {
+				continue
+			}
+			ch <- metric
+		}
+	}
+}
+
+// exporterKey identifies the benchmark CR of the given kind
+func exporterKey(kind string, cr types.NamespacedName) string {
+	return kind + "/" + cr.String()
+}
+
+// mergeLabels returns the union of the label sets with sanitized names.
+// The labels of the latter override the labels of the former.
+func mergeLabels(labels, overrides map[string]string) map[string]string {
+	merged := map[string]string{}
+	for name, value := range labels {
+		merged[sanitizeName(name)] = value
+	}
+	for name, value := range overrides {
+		merged[sanitizeName(name)] = value
+	}
+	return merged
+}
+
+// sanitizeName replaces the characters which are invalid in Prometheus
+// metric and label names
+func sanitizeName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
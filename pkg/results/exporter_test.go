@@ -0,0 +1,145 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// gather collects the metric families of the exporter by name
+func gather(exporter *Exporter) map[string]*dto.MetricFamily {
+	registry := prometheus.NewRegistry()
+	Expect(registry.Register(exporter)).To(Succeed())
+	families, err := registry.Gather()
+	Expect(err).To(BeNil())
+
+	byName := map[string]*dto.MetricFamily{}
+	for _, family := range families {
+		byName[family.GetName()] = family
+	}
+	return byName
+}
+
+// labelsOf returns the labels of the metric as a map
+func labelsOf(metric *dto.Metric) map[string]string {
+	labels := map[string]string{}
+	for _, pair := range metric.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	return labels
+}
+
+var _ = Describe("Results exporter", func() {
+	var exporter *Exporter
+	var status perfv1alpha1.BenchmarkStatus
+	cr := types.NamespacedName{Namespace: "kubestone", Name: "fio-sample"}
+
+	BeforeEach(func() {
+		exporter = NewExporter()
+		start := metav1.NewTime(time.Date(2019, 11, 1, 2, 0, 0, 0, time.UTC))
+		completion := metav1.NewTime(start.Add(90 * time.Second))
+		status = perfv1alpha1.BenchmarkStatus{
+			Phase:          perfv1alpha1.BenchmarkSucceeded,
+			StartTime:      &start,
+			CompletionTime: &completion,
+			Results: &perfv1alpha1.BenchmarkResults{Metrics: []perfv1alpha1.BenchmarkMetric{
+				{Name: "iops", Value: "470", Labels: map[string]string{"job": "randwrite", "rw": "write"}, Job: "fio-sample"},
+				{Name: "iops", Value: "520", Labels: map[string]string{"job": "randread", "rw": "read"}, Job: "fio-sample"},
+				{Name: "runtime_seconds", Value: "1.5e+01"},
+			}},
+		}
+	})
+
+	It("should export the metrics of the results labeled with the CR", func() {
+		exporter.SetResults("Fio", cr, &status)
+		families := gather(exporter)
+
+		Expect(families).To(HaveKey("kubestone_fio_iops"))
+		iops := families["kubestone_fio_iops"]
+		Expect(iops.GetType()).To(Equal(dto.MetricType_GAUGE))
+		Expect(iops.GetMetric()).To(HaveLen(2))
+		for _, metric := range iops.GetMetric() {
+			labels := labelsOf(metric)
+			Expect(labels).To(HaveKeyWithValue("cr", "fio-sample"))
+			Expect(labels).To(HaveKeyWithValue("namespace", "kubestone"))
+			Expect(labels).To(HaveKeyWithValue("job", "fio-sample"))
+			if labels["rw"] == "write" {
+				Expect(labels).To(HaveKeyWithValue("fio_job", "randwrite"))
+				Expect(metric.GetGauge().GetValue()).To(Equal(470.0))
+			}
+		}
+
+		Expect(families).To(HaveKey("kubestone_fio_runtime_seconds"))
+		runtime := families["kubestone_fio_runtime_seconds"].GetMetric()[0]
+		Expect(runtime.GetGauge().GetValue()).To(Equal(15.0))
+		Expect(labelsOf(runtime)).NotTo(HaveKey("job"))
+	})
+
+	It("should export the duration of the benchmark", func() {
+		exporter.SetResults("Fio", cr, &status)
+		families := gather(exporter)
+
+		Expect(families).To(HaveKey(DurationMetric))
+		duration := families[DurationMetric].GetMetric()[0]
+		Expect(duration.GetGauge().GetValue()).To(Equal(90.0))
+		Expect(labelsOf(duration)).To(Equal(map[string]string{
+			"cr":        "fio-sample",
+			"namespace": "kubestone",
+			"kind":      "Fio",
+			"phase":     "Succeeded",
+		}))
+	})
+
+	It("should replace the metrics of the CR", func() {
+		exporter.SetResults("Fio", cr, &status)
+		status.Results = nil
+		exporter.SetResults("Fio", cr, &status)
+		families := gather(exporter)
+
+		Expect(families).NotTo(HaveKey("kubestone_fio_iops"))
+		Expect(families).To(HaveKey(DurationMetric))
+	})
+
+	It("should remove the metrics of deleted CRs", func() {
+		exporter.SetResults("Fio", cr, &status)
+		exporter.DeleteResults("Fio", cr)
+		Expect(gather(exporter)).To(BeEmpty())
+	})
+
+	It("should not fail the scrape with duplicated or invalid metrics", func() {
+		status.Results.Metrics = append(status.Results.Metrics,
+			perfv1alpha1.BenchmarkMetric{Name: "iops", Value: "1", Labels: map[string]string{"job": "randread", "rw": "read"}, Job: "fio-sample"},
+			perfv1alpha1.BenchmarkMetric{Name: "iops", Value: "NaN?"},
+			perfv1alpha1.BenchmarkMetric{Name: "latency.p99", Value: "1", Labels: map[string]string{"latency-type": "p99"}},
+		)
+		exporter.SetResults("Fio", cr, &status)
+		families := gather(exporter)
+
+		Expect(families["kubestone_fio_iops"].GetMetric()).To(HaveLen(2))
+		Expect(families).To(HaveKey("kubestone_fio_latency_p99"))
+		Expect(labelsOf(families["kubestone_fio_latency_p99"].GetMetric()[0])).To(HaveKey("latency_type"))
+	})
+})
@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	fioJobRegexp         = regexp.MustCompile(`^(\S[^:]*): \(groupid=\d+`)
+	fioIOPSRegexp        = regexp.MustCompile(`^\s+(read|write|trim)\s*: IOPS=([\d.]+)([kMG]?), BW=([\d.]+)([KMGT]?i?)B/s`)
+	fioClatRegexp        = regexp.MustCompile(`^\s+clat \((\w+)\):.*avg=\s*([\d.]+)`)
+	fioPercentilesRegexp = regexp.MustCompile(`^\s+clat percentiles \((\w+)\):`)
+	fioPercentileRegexp  = regexp.MustCompile(`([\d.]+)th=\[\s*(\d+)\]`)
+)
+
+// ParseFio parses the default (normal) output of fio. For each job and
+// data direction the IOPS, the bandwidth, the average completion latency
+// and the completion latency percentiles are returned.
+func ParseFio(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	var labels map[string]string
+	job := ""
+	percentileUnit := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if percentileUnit != "" {
+			if strings.HasPrefix(strings.TrimSpace(line), "|") {
+				for _, match := range fioPercentileRegexp.FindAllStringSubmatch(line, -1) {
+					latency, err := parseDuration(match[2], percentileUnit)
+					if err != nil {
+						return nil, err
+					}
+					percentileLabels := map[string]string{"percentile": match[1]}
+					for key, value := range labels {
+						percentileLabels[key] = value
+					}
+					metrics = append(metrics, newMetric("completion_latency_seconds", latency, UnitSeconds, percentileLabels))
+				}
+				continue
+			}
+			percentileUnit = ""
+		}
+
+		if match := fioJobRegexp.FindStringSubmatch(line); match != nil {
+			job = match[1]
+			labels = nil
+			continue
+		}
+		if match := fioIOPSRegexp.FindStringSubmatch(line); match != nil && job != "" {
+			labels = map[string]string{"job": job, "rw": match[1]}
+			iops, err := parseScaled(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			bandwidth, err := parseScaled(match[4], match[5])
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics,
+				newMetric("iops", iops, UnitOperationsPerSecond, labels),
+				newMetric("bandwidth_bytes_per_second", bandwidth, UnitBytesPerSecond, labels))
+			continue
+		}
+		if labels == nil {
+			continue
+		}
+		if match := fioClatRegexp.FindStringSubmatch(line); match != nil {
+			latency, err := parseDuration(match[2], match[1])
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("completion_latency_avg_seconds", latency, UnitSeconds, labels))
+			continue
+		}
+		if match := fioPercentilesRegexp.FindStringSubmatch(line); match != nil {
+			percentileUnit = match[1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(metrics) == 0 {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
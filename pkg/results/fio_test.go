@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const fioOutput = `rand-read: (g=0): rw=randread, bs=(R) 4096B-4096B, (W) 4096B-4096B, ioengine=libaio, iodepth=16
+fio-3.13
+Starting 1 process
+rand-read: Laying out IO file (1 file / 100MiB)
+
+rand-read: (groupid=0, jobs=1): err= 0: pid=17: Mon Nov 11 12:00:00 2019
+  read: IOPS=12.3k, BW=48.1MiB/s (50.4MB/s)(481MiB/10001msec)
+    slat (usec): min=2, max=123, avg= 4.12, stdev= 1.23
+    clat (usec): min=30, max=5000, avg=1250.50, stdev=20.00
+     lat (usec): min=33, max=5003, avg=1254.62, stdev=20.10
+    clat percentiles (usec):
+     |  1.00th=[   51],  5.00th=[   55], 10.00th=[   57], 20.00th=[   61],
+     | 50.00th=[  750], 99.00th=[ 2135], 99.90th=[ 4260]
+   bw (  KiB/s): min=40000, max=52000, per=100.00%, avg=49250.10, stdev=1000.00, samples=20
+  write: IOPS=512, BW=2048KiB/s (2097kB/s)(20.0MiB/10001msec)
+    clat (nsec): min=3000, max=90000, avg=15000.00, stdev=200.00
+    clat percentiles (nsec):
+     | 50.00th=[14000], 99.00th=[80000]
+  cpu          : usr=2.00%, sys=8.00%, ctx=123456, majf=0, minf=25
+
+Run status group 0 (all jobs):
+   READ: bw=48.1MiB/s (50.4MB/s), 48.1MiB/s-48.1MiB/s (50.4MB/s-50.4MB/s), io=481MiB (504MB), run=10001-10001msec
+`
+
+var _ = Describe("fio parser", func() {
+	It("parses the IOPS, bandwidth and latencies per data direction", func() {
+		metrics, err := ParseFio(fioOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		read := map[string]string{"job": "rand-read", "rw": "read"}
+		write := map[string]string{"job": "rand-read", "rw": "write"}
+		Expect(findMetric(metrics, "iops", read)).To(Equal("12300"))
+		Expect(findMetric(metrics, "bandwidth_bytes_per_second", read)).To(Equal("50436505.6"))
+		Expect(findMetric(metrics, "completion_latency_avg_seconds", read)).To(Equal("0.0012505"))
+		Expect(findMetric(metrics, "completion_latency_seconds",
+			map[string]string{"job": "rand-read", "rw": "read", "percentile": "99.00"})).To(Equal("0.002135"))
+		Expect(findMetric(metrics, "completion_latency_seconds",
+			map[string]string{"job": "rand-read", "rw": "read", "percentile": "99.90"})).To(Equal("0.00426"))
+
+		Expect(findMetric(metrics, "iops", write)).To(Equal("512"))
+		Expect(findMetric(metrics, "bandwidth_bytes_per_second", write)).To(Equal("2097152"))
+		Expect(findMetric(metrics, "completion_latency_avg_seconds", write)).To(Equal("1.5e-05"))
+		Expect(findMetric(metrics, "completion_latency_seconds",
+			map[string]string{"job": "rand-read", "rw": "write", "percentile": "99.00"})).To(Equal("8e-05"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseFio("fio: failed to open file")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
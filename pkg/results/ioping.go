@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	iopingCompletedRegexp = regexp.MustCompile(
+		`^(\d+) requests completed in .*?([\d.]+) ([kMG]?)\s*iops, ([\d.]+) ([KMGT]?i?)B/s`)
+	iopingLatencyRegexp = regexp.MustCompile(
+		`^min/avg/max/mdev = ([\d.]+) (\S+) / ([\d.]+) (\S+) / ([\d.]+) (\S+) / ([\d.]+) (\S+)`)
+)
+
+// ParseIoping parses the statistics printed by ioping at exit
+func ParseIoping(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	completedFound := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := iopingCompletedRegexp.FindStringSubmatch(line); match != nil {
+			requests, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return nil, err
+			}
+			iops, err := parseScaled(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			bandwidth, err := parseScaled(match[4], match[5])
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics,
+				newMetric("requests", requests, "", nil),
+				newMetric("iops", iops, UnitOperationsPerSecond, nil),
+				newMetric("bandwidth_bytes_per_second", bandwidth, UnitBytesPerSecond, nil))
+			completedFound = true
+			continue
+		}
+		if match := iopingLatencyRegexp.FindStringSubmatch(line); match != nil {
+			for i, statistic := range []string{"min", "avg", "max", "mdev"} {
+				latency, err := parseDuration(match[2*i+1], match[2*i+2])
+				if err != nil {
+					return nil, err
+				}
+				metrics = append(metrics, newMetric("latency_"+statistic+"_seconds", latency, UnitSeconds, nil))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !completedFound {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
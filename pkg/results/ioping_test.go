@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const iopingOutput = `4 KiB <<< /mnt/test (ext4 /dev/sda1): request=1 time=298.4 us (warmup)
+4 KiB <<< /mnt/test (ext4 /dev/sda1): request=2 time=571.2 us
+
+--- /mnt/test (ext4 /dev/sda1) ioping statistics ---
+9 requests completed in 3.67 ms, 36 KiB read, 2.45 k iops, 9.59 MiB/s
+generated 10 requests in 9.00 s, 40 KiB, 1 iops, 4.44 KiB/s
+min/avg/max/mdev = 298.4 us / 408.0 us / 1.2 ms / 87.0 us
+`
+
+var _ = Describe("ioping parser", func() {
+	It("parses the statistics", func() {
+		metrics, err := ParseIoping(iopingOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "requests", nil)).To(Equal("9"))
+		Expect(findMetric(metrics, "iops", nil)).To(Equal("2450"))
+		Expect(findMetric(metrics, "bandwidth_bytes_per_second", nil)).To(Equal("10055843.84"))
+		Expect(findMetric(metrics, "latency_min_seconds", nil)).To(Equal("0.0002984"))
+		Expect(findMetric(metrics, "latency_avg_seconds", nil)).To(Equal("0.000408"))
+		Expect(findMetric(metrics, "latency_max_seconds", nil)).To(Equal("0.0012"))
+		Expect(findMetric(metrics, "latency_mdev_seconds", nil)).To(Equal("8.7e-05"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseIoping("ioping: no such file or directory")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	iperf3SummaryRegexp = regexp.MustCompile(
+		`^\[\s*(\d+|SUM)\]\s+[\d.]+-[\d.]+\s+sec\s+([\d.]+) ([KMGT]?)Bytes\s+([\d.]+) ([KMGT]?)bits/sec\s*(.*?)\s*(sender|receiver)\s*$`)
+	iperf3UDPRegexp = regexp.MustCompile(`^([\d.]+) ms\s+\d+/\d+ \(([\d.e+-]+)%\)`)
+)
+
+// iperf3BytePrefixes contains the multipliers of the prefixes used by
+// iperf3 for the transferred data, which are powers of 1024.
+var iperf3BytePrefixes = map[string]string{"": "", "K": "Ki", "M": "Mi", "G": "Gi", "T": "Ti"}
+
+type iperf3Summary struct {
+	bytes       float64
+	bitsPerSec  float64
+	extra       string
+	fromSumLine bool
+}
+
+// ParseIperf3 parses the summary lines of the iperf3 client output. When
+// multiple parallel streams are used the [SUM] lines are preferred over
+// the per stream ones. For UDP tests the jitter and the datagram loss
+// reported by the receiver are returned as well.
+func ParseIperf3(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	summaries := map[string]*iperf3Summary{}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := iperf3SummaryRegexp.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		direction := match[7]
+		sumLine := match[1] == "SUM"
+		if previous, ok := summaries[direction]; ok && previous.fromSumLine && !sumLine {
+			continue
+		}
+
+		transferred, err := parseScaled(match[2], iperf3BytePrefixes[match[3]])
+		if err != nil {
+			return nil, err
+		}
+		bitsPerSec, err := parseScaled(match[4], match[5])
+		if err != nil {
+			return nil, err
+		}
+		summaries[direction] = &iperf3Summary{
+			bytes:       transferred,
+			bitsPerSec:  bitsPerSec,
+			extra:       match[6],
+			fromSumLine: sumLine,
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var metrics []perfv1alpha1.BenchmarkMetric
+	for _, direction := range []string{"sender", "receiver"} {
+		summary, ok := summaries[direction]
+		if !ok {
+			continue
+		}
+		labels := map[string]string{"direction": direction}
+		metrics = append(metrics,
+			newMetric("bits_per_second", summary.bitsPerSec, UnitBitsPerSecond, labels),
+			newMetric("transferred_bytes", summary.bytes, UnitBytes, labels))
+
+		if match := iperf3UDPRegexp.FindStringSubmatch(summary.extra); match != nil {
+			if direction != "receiver" {
+				continue
+			}
+			jitter, err := parseDuration(match[1], "ms")
+			if err != nil {
+				return nil, err
+			}
+			lost, err := strconv.ParseFloat(match[2], 64)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics,
+				newMetric("jitter_seconds", jitter, UnitSeconds, labels),
+				newMetric("lost_datagrams_percent", lost, UnitPercent, labels))
+		} else if direction == "sender" && summary.extra != "" {
+			retransmits, err := strconv.ParseFloat(summary.extra, 64)
+			if err == nil {
+				metrics = append(metrics, newMetric("retransmits", retransmits, "", labels))
+			}
+		}
+	}
+
+	if len(metrics) == 0 {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
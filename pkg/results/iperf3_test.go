@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const iperf3TCPOutput = `Connecting to host iperf3-sample, port 5201
+[  5] local 10.244.0.7 port 41234 connected to 10.96.12.3 port 5201
+[  7] local 10.244.0.7 port 41236 connected to 10.96.12.3 port 5201
+[ ID] Interval           Transfer     Bitrate         Retr  Cwnd
+[  5]   0.00-1.00   sec   112 MBytes   940 Mbits/sec    0    400 KBytes
+- - - - - - - - - - - - - - - - - - - - - - - - -
+[ ID] Interval           Transfer     Bitrate         Retr
+[  5]   0.00-10.00  sec   550 MBytes   461 Mbits/sec   12             sender
+[  5]   0.00-10.04  sec   548 MBytes   458 Mbits/sec                  receiver
+[  7]   0.00-10.00  sec   550 MBytes   461 Mbits/sec    3             sender
+[  7]   0.00-10.04  sec   548 MBytes   458 Mbits/sec                  receiver
+[SUM]   0.00-10.00  sec  1.07 GBytes   922 Mbits/sec   15             sender
+[SUM]   0.00-10.04  sec  1.07 GBytes   916 Mbits/sec                  receiver
+
+iperf Done.
+`
+
+const iperf3UDPOutput = `Connecting to host iperf3-sample, port 5201
+[ ID] Interval           Transfer     Bitrate         Jitter    Lost/Total Datagrams
+[  5]   0.00-10.00  sec  1.25 MBytes  1.05 Mbits/sec  0.000 ms  0/906 (0%)  sender
+[  5]   0.00-10.04  sec  1.25 MBytes  1.04 Mbits/sec  0.012 ms  9/906 (0.99%)  receiver
+
+iperf Done.
+`
+
+var _ = Describe("iperf3 parser", func() {
+	It("prefers the SUM lines of parallel streams", func() {
+		metrics, err := ParseIperf3(iperf3TCPOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		sender := map[string]string{"direction": "sender"}
+		receiver := map[string]string{"direction": "receiver"}
+		Expect(findMetric(metrics, "bits_per_second", sender)).To(Equal("922000000"))
+		Expect(findMetric(metrics, "bits_per_second", receiver)).To(Equal("916000000"))
+		Expect(findMetric(metrics, "retransmits", sender)).To(Equal("15"))
+		Expect(findMetric(metrics, "transferred_bytes", receiver)).To(Equal("1148903752"))
+	})
+
+	It("parses the jitter and datagram loss of UDP tests", func() {
+		metrics, err := ParseIperf3(iperf3UDPOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		receiver := map[string]string{"direction": "receiver"}
+		Expect(findMetric(metrics, "bits_per_second", receiver)).To(Equal("1040000"))
+		Expect(findMetric(metrics, "jitter_seconds", receiver)).To(Equal("1.2e-05"))
+		Expect(findMetric(metrics, "lost_datagrams_percent", receiver)).To(Equal("0.99"))
+		Expect(findMetric(metrics, "jitter_seconds", map[string]string{"direction": "sender"})).To(BeEmpty())
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseIperf3("iperf3: error - unable to connect to server: Connection refused")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
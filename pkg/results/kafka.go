@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	kafkaProducerRegexp = regexp.MustCompile(
+		`^(\d+) records sent, ([\d.]+) records/sec \(([\d.]+) MB/sec\), ([\d.]+) ms avg latency, ([\d.]+) ms max latency(.*)$`)
+	kafkaPercentileRegexp = regexp.MustCompile(`(\d+) ms ([\d.]+)th`)
+)
+
+// kafkaMegabyte is the size of the MB unit used by the kafka perf tools
+const kafkaMegabyte = 1024 * 1024
+
+// ParseKafkaProducer parses the final summary of kafka-producer-perf-test
+func ParseKafkaProducer(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var summary []string
+
+	// The tool prints intermediate results as well, the last line
+	// containing the percentiles is the summary of the whole test
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := kafkaProducerRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match != nil && kafkaPercentileRegexp.MatchString(match[6]) {
+			summary = match
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, ErrNoResults
+	}
+
+	values := make([]float64, 5)
+	for i := range values {
+		value, err := strconv.ParseFloat(summary[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	metrics := []perfv1alpha1.BenchmarkMetric{
+		newMetric("records", values[0], "", nil),
+		newMetric("records_per_second", values[1], UnitOperationsPerSecond, nil),
+		newMetric("bandwidth_bytes_per_second", values[2]*kafkaMegabyte, UnitBytesPerSecond, nil),
+		newMetric("latency_avg_seconds", values[3]/1e3, UnitSeconds, nil),
+		newMetric("latency_max_seconds", values[4]/1e3, UnitSeconds, nil),
+	}
+	for _, match := range kafkaPercentileRegexp.FindAllStringSubmatch(summary[6], -1) {
+		latency, err := parseDuration(match[1], "ms")
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, newMetric("latency_seconds", latency, UnitSeconds,
+			map[string]string{"percentile": match[2]}))
+	}
+	return metrics, nil
+}
+
+// ParseKafkaConsumer parses the CSV like summary of kafka-consumer-perf-test
+func ParseKafkaConsumer(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var header []string
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if fields[0] == "start.time" {
+			header = fields
+			continue
+		}
+		if header == nil || len(fields) != len(header) {
+			continue
+		}
+
+		var metrics []perfv1alpha1.BenchmarkMetric
+		for i, column := range header {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			switch column {
+			case "data.consumed.in.nMsg":
+				metrics = append(metrics, newMetric("records", value, "", nil))
+			case "nMsg.sec":
+				metrics = append(metrics, newMetric("records_per_second", value, UnitOperationsPerSecond, nil))
+			case "MB.sec":
+				metrics = append(metrics, newMetric("bandwidth_bytes_per_second", value*kafkaMegabyte, UnitBytesPerSecond, nil))
+			}
+		}
+		if len(metrics) > 0 {
+			return metrics, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, ErrNoResults
+}
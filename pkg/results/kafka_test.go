@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const kafkaProducerOutput = `50002 records sent, 10000.4 records/sec (9.54 MB/sec), 120.5 ms avg latency, 500.0 ms max latency.
+100000 records sent, 24319.066148 records/sec (23.19 MB/sec), 829.55 ms avg latency, 1308.00 ms max latency, 833 ms 50th, 1219 ms 95th, 1289 ms 99th, 1305 ms 99.9th.
+`
+
+const kafkaConsumerOutput = `start.time, end.time, data.consumed.in.MB, MB.sec, data.consumed.in.nMsg, nMsg.sec, rebalance.time.ms, fetch.time.ms, fetch.MB.sec, fetch.nMsg.sec
+2019-11-14 10:00:00:000, 2019-11-14 10:00:10:000, 95.3674, 9.5, 100000, 10000.0000, 3000, 7000, 13.6239, 14285.7143
+`
+
+var _ = Describe("kafka parsers", func() {
+	It("parses the final summary of the producer", func() {
+		metrics, err := ParseKafkaProducer(kafkaProducerOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "records", nil)).To(Equal("100000"))
+		Expect(findMetric(metrics, "records_per_second", nil)).To(Equal("24319.06615"))
+		Expect(findMetric(metrics, "latency_avg_seconds", nil)).To(Equal("0.82955"))
+		Expect(findMetric(metrics, "latency_seconds", map[string]string{"percentile": "99.9"})).To(Equal("1.305"))
+	})
+
+	It("parses the summary of the consumer", func() {
+		metrics, err := ParseKafkaConsumer(kafkaConsumerOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "records", nil)).To(Equal("100000"))
+		Expect(findMetric(metrics, "records_per_second", nil)).To(Equal("10000"))
+		Expect(findMetric(metrics, "bandwidth_bytes_per_second", nil)).To(Equal("9961472"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseKafkaProducer("org.apache.kafka.common.errors.TimeoutException")
+		Expect(err).To(Equal(ErrNoResults))
+		_, err = ParseKafkaConsumer("WARNING: Exiting before consuming the expected number of messages")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// minOutlierNodes is the number of nodes needed to tell the outliers
+// apart: with two nodes both of them deviate from the median equally
+const minOutlierNodes = 3
+
+// FindOutliers compares the per node metrics of a fanned out benchmark,
+// labeled with perfv1alpha1.FanOutNodeLabel, with the median of the same
+// metric (same name and other labels) on every node. Metrics deviating
+// from the median by more than the tolerance (in percent) are returned.
+func FindOutliers(metrics []perfv1alpha1.BenchmarkMetric, tolerance float64) ([]perfv1alpha1.MetricOutlier, error) {
+	type group struct {
+		name   string
+		labels map[string]string
+		nodes  []string
+		values []float64
+	}
+	var groups []*group
+	for _, metric := range metrics {
+		node, ok := metric.Labels[perfv1alpha1.FanOutNodeLabel]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of metric %s: %v", metric.Name, err)
+		}
+		labels := withoutNode(metric.Labels)
+
+		var current *group
+		for _, g := range groups {
+			if g.name == metric.Name && sameLabels(g.labels, labels) {
+				current = g
+				break
+			}
+		}
+		if current == nil {
+			current = &group{name: metric.Name, labels: labels}
+			groups = append(groups, current)
+		}
+		current.nodes = append(current.nodes, node)
+		current.values = append(current.values, value)
+	}
+
+	var outliers []perfv1alpha1.MetricOutlier
+	for _, g := range groups {
+		if len(g.values) < minOutlierNodes {
+			continue
+		}
+		median := medianOf(g.values)
+		if median == 0 {
+			continue
+		}
+		for i, value := range g.values {
+			delta := (value - median) / math.Abs(median) * 100
+			if math.Abs(delta) <= tolerance {
+				continue
+			}
+			outliers = append(outliers, perfv1alpha1.MetricOutlier{
+				Node:   g.nodes[i],
+				Name:   g.name,
+				Labels: g.labels,
+				Value:  strconv.FormatFloat(value, 'g', 10, 64),
+				Median: strconv.FormatFloat(median, 'g', 10, 64),
+				Delta:  strconv.FormatFloat(delta, 'f', 2, 64),
+			})
+		}
+	}
+	return outliers, nil
+}
+
+// medianOf returns the median of the values
+func medianOf(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// withoutNode returns the labels apart from the node label
+func withoutNode(labels map[string]string) map[string]string {
+	var others map[string]string
+	for key, value := range labels {
+		if key == perfv1alpha1.FanOutNodeLabel {
+			continue
+		}
+		if others == nil {
+			others = map[string]string{}
+		}
+		others[key] = value
+	}
+	return others
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// nodeMetric returns an iops metric of the given node
+func nodeMetric(node, rw string, value float64) perfv1alpha1.BenchmarkMetric {
+	return newMetric("iops", value, UnitOperationsPerSecond,
+		map[string]string{perfv1alpha1.FanOutNodeLabel: node, "rw": rw})
+}
+
+var _ = Describe("Outliers", func() {
+	It("should report the metrics deviating from the median of the nodes", func() {
+		outliers, err := FindOutliers([]perfv1alpha1.BenchmarkMetric{
+			nodeMetric("node-1", "read", 1000),
+			nodeMetric("node-1", "write", 500),
+			nodeMetric("node-2", "read", 1050),
+			nodeMetric("node-2", "write", 510),
+			nodeMetric("node-3", "read", 600),
+			nodeMetric("node-3", "write", 490),
+		}, 10)
+		Expect(err).To(BeNil())
+		Expect(outliers).To(HaveLen(1))
+		Expect(outliers[0].Node).To(Equal("node-3"))
+		Expect(outliers[0].Labels).To(Equal(map[string]string{"rw": "read"}))
+		Expect(outliers[0].Median).To(Equal("1000"))
+		Expect(outliers[0].Delta).To(Equal("-40.00"))
+	})
+
+	It("should need at least three nodes", func() {
+		outliers, err := FindOutliers([]perfv1alpha1.BenchmarkMetric{
+			nodeMetric("node-1", "read", 1000),
+			nodeMetric("node-2", "read", 100),
+		}, 10)
+		Expect(err).To(BeNil())
+		Expect(outliers).To(BeEmpty())
+	})
+
+	It("should ignore the metrics without node", func() {
+		outliers, err := FindOutliers([]perfv1alpha1.BenchmarkMetric{
+			newMetric("iops", 1, UnitOperationsPerSecond, nil),
+		}, 10)
+		Expect(err).To(BeNil())
+		Expect(outliers).To(BeEmpty())
+	})
+})
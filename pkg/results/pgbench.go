@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	pgbenchTPSRegexp       = regexp.MustCompile(`^tps = ([\d.]+) \((.*)\)`)
+	pgbenchLatencyRegexp   = regexp.MustCompile(`^latency (average|stddev) = ([\d.]+) (\w+)`)
+	pgbenchProcessedRegexp = regexp.MustCompile(`^number of transactions actually processed: (\d+)`)
+)
+
+// ParsePgbench parses the summary printed by pgbench. The tps metric
+// excludes the time of establishing the connections, the one including
+// it is returned as tps_including_connections.
+func ParsePgbench(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	tpsFound := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := pgbenchTPSRegexp.FindStringSubmatch(line); match != nil {
+			tps, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return nil, err
+			}
+			name := "tps"
+			if strings.HasPrefix(match[2], "including") {
+				name = "tps_including_connections"
+			} else {
+				tpsFound = true
+			}
+			metrics = append(metrics, newMetric(name, tps, UnitOperationsPerSecond, nil))
+			continue
+		}
+		if match := pgbenchLatencyRegexp.FindStringSubmatch(line); match != nil {
+			latency, err := parseDuration(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			name := "latency_avg_seconds"
+			if match[1] == "stddev" {
+				name = "latency_stddev_seconds"
+			}
+			metrics = append(metrics, newMetric(name, latency, UnitSeconds, nil))
+			continue
+		}
+		if match := pgbenchProcessedRegexp.FindStringSubmatch(line); match != nil {
+			processed, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("transactions", processed, "", nil))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !tpsFound {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
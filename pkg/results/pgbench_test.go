@@ -0,0 +1,60 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const pgbenchOutput = `starting vacuum...end.
+transaction type: <builtin: TPC-B (sort of)>
+scaling factor: 1
+query mode: simple
+number of clients: 10
+number of threads: 1
+number of transactions per client: 100
+number of transactions actually processed: 1000/1000
+latency average = 15.844 ms
+latency stddev = 2.5 ms
+tps = 631.131622 (including connections establishing)
+tps = 633.395339 (excluding connections establishing)
+`
+
+var _ = Describe("pgbench parser", func() {
+	It("parses the transactions per second and latencies", func() {
+		metrics, err := ParsePgbench(pgbenchOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "tps", nil)).To(Equal("633.395339"))
+		Expect(findMetric(metrics, "tps_including_connections", nil)).To(Equal("631.131622"))
+		Expect(findMetric(metrics, "latency_avg_seconds", nil)).To(Equal("0.015844"))
+		Expect(findMetric(metrics, "latency_stddev_seconds", nil)).To(Equal("0.0025"))
+		Expect(findMetric(metrics, "transactions", nil)).To(Equal("1000"))
+	})
+
+	It("parses the output of recent pgbench versions", func() {
+		metrics, err := ParsePgbench("tps = 1234.5 (without initial connection time)\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findMetric(metrics, "tps", nil)).To(Equal("1234.5"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParsePgbench("pgbench: error: connection to database failed")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
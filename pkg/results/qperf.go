@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	qperfTestRegexp  = regexp.MustCompile(`^(\w+):\s*$`)
+	qperfValueRegexp = regexp.MustCompile(`^\s+(bw|latency|msg_rate)\s+=\s+([\d.]+) ([KMGT]?)(\S*)`)
+)
+
+// ParseQperf parses the bandwidth, latency and message rate results of the
+// qperf tests. The metrics are labeled with the name of the test.
+func ParseQperf(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	test := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := qperfTestRegexp.FindStringSubmatch(line); match != nil {
+			test = match[1]
+			continue
+		}
+		match := qperfValueRegexp.FindStringSubmatch(line)
+		if match == nil || test == "" {
+			continue
+		}
+
+		labels := map[string]string{"test": test}
+		switch match[1] {
+		case "bw":
+			bandwidth, err := parseScaled(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(match[4], "b/") {
+				bandwidth /= 8
+			}
+			metrics = append(metrics, newMetric("bandwidth_bytes_per_second", bandwidth, UnitBytesPerSecond, labels))
+		case "latency":
+			latency, err := parseDuration(match[2], match[3]+match[4])
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("latency_seconds", latency, UnitSeconds, labels))
+		case "msg_rate":
+			rate, err := parseScaled(match[2], match[3])
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("messages_per_second", rate, UnitOperationsPerSecond, labels))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(metrics) == 0 {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const qperfOutput = `tcp_bw:
+    bw  =  1.17 GB/sec
+tcp_lat:
+    latency  =  31.2 us
+udp_bw:
+    send_bw  =  600 MB/sec
+    recv_bw  =  590 MB/sec
+rc_rdma_write_lat:
+    msg_rate  =  1.5 K/sec
+`
+
+var _ = Describe("qperf parser", func() {
+	It("parses the results of each test", func() {
+		metrics, err := ParseQperf(qperfOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "bandwidth_bytes_per_second", map[string]string{"test": "tcp_bw"})).To(Equal("1170000000"))
+		Expect(findMetric(metrics, "latency_seconds", map[string]string{"test": "tcp_lat"})).To(Equal("3.12e-05"))
+		Expect(findMetric(metrics, "messages_per_second", map[string]string{"test": "rc_rdma_write_lat"})).To(Equal("1500"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseQperf("qperf: failed to connect")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
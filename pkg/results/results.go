@@ -0,0 +1,195 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// Units used in the parsed metrics
+const (
+	// UnitSeconds is used for durations and latencies
+	UnitSeconds = "s"
+	// UnitBytesPerSecond is used for storage and network bandwidth
+	UnitBytesPerSecond = "B/s"
+	// UnitBitsPerSecond is used for network bandwidth
+	UnitBitsPerSecond = "bit/s"
+	// UnitBytes is used for transferred data
+	UnitBytes = "B"
+	// UnitOperationsPerSecond is used for IOPS, transactions, requests, etc.
+	UnitOperationsPerSecond = "1/s"
+	// UnitPercent is used for ratios
+	UnitPercent = "%"
+)
+
+// maxOutputSize limits the output of a benchmark container read for parsing.
+// The summaries, which are parsed, are at the end of the output.
+const maxOutputSize = 16 << 20
+
+// Parser extracts the metrics from the output of a benchmark
+type Parser func(output string) ([]perfv1alpha1.BenchmarkMetric, error)
+
+// ErrNoResults is returned by the parsers when the output does not
+// contain the summary of the benchmark
+var ErrNoResults = errors.New("no benchmark results found in the output")
+
+// Collect reads the logs of the succeeded pods of the job and parses them
+// with the given parser. If the job has more than one succeeded pod, the
+// metrics are labeled with the name of the pod they were parsed from.
+func Collect(access *k8s.Access, job types.NamespacedName, parse Parser) ([]perfv1alpha1.BenchmarkMetric, error) {
+	podList, err := access.GetJobPods(job)
+	if err != nil {
+		return nil, err
+	}
+	if podList == nil {
+		return nil, fmt.Errorf("unable to list the pods of job %v", job)
+	}
+
+	var succeededPods []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded && len(pod.Spec.Containers) > 0 {
+			succeededPods = append(succeededPods, pod)
+		}
+	}
+	if len(succeededPods) == 0 {
+		return nil, fmt.Errorf("job %v has no succeeded pods", job)
+	}
+
+	var metrics []perfv1alpha1.BenchmarkMetric
+	for _, pod := range succeededPods {
+		logs, err := access.GetPodLogs(
+			types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name},
+			pod.Spec.Containers[0].Name, maxOutputSize)
+		if err != nil {
+			return nil, err
+		}
+
+		podMetrics, err := parse(logs)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the output of pod %v: %v", pod.Name, err)
+		}
+		if len(succeededPods) > 1 {
+			podMetrics = WithLabels(podMetrics, map[string]string{"pod": pod.Name})
+		}
+		metrics = append(metrics, podMetrics...)
+	}
+
+	return metrics, nil
+}
+
+// NewResults creates the results to be stored in the status of the CR
+func NewResults(metrics []perfv1alpha1.BenchmarkMetric) *perfv1alpha1.BenchmarkResults {
+	now := metav1.Now()
+	return &perfv1alpha1.BenchmarkResults{
+		Metrics:        metrics,
+		CollectionTime: &now,
+	}
+}
+
+// WithLabels adds the given labels to each of the metrics
+func WithLabels(metrics []perfv1alpha1.BenchmarkMetric, labels map[string]string) []perfv1alpha1.BenchmarkMetric {
+	for i := range metrics {
+		if metrics[i].Labels == nil {
+			metrics[i].Labels = map[string]string{}
+		}
+		for key, value := range labels {
+			metrics[i].Labels[key] = value
+		}
+	}
+	return metrics
+}
+
+// newMetric creates a metric with the value formatted as a decimal number
+func newMetric(name string, value float64, unit string, labels map[string]string) perfv1alpha1.BenchmarkMetric {
+	var metricLabels map[string]string
+	if len(labels) > 0 {
+		metricLabels = make(map[string]string, len(labels))
+		for key, value := range labels {
+			metricLabels[key] = value
+		}
+	}
+
+	return perfv1alpha1.BenchmarkMetric{
+		Name:   name,
+		Value:  strconv.FormatFloat(value, 'g', 10, 64),
+		Unit:   unit,
+		Labels: metricLabels,
+	}
+}
+
+// unitPrefixes contains the multipliers of the decimal and binary prefixes
+var unitPrefixes = map[string]float64{
+	"":   1,
+	"k":  1e3,
+	"K":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+}
+
+// parseScaled parses a number and multiplies it with the given unit prefix
+func parseScaled(number, prefix string) (float64, error) {
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, err
+	}
+	multiplier, ok := unitPrefixes[prefix]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit prefix: %q", prefix)
+	}
+	return value * multiplier, nil
+}
+
+// timeUnits contains the duration of the time units in seconds
+var timeUnits = map[string]float64{
+	"ns":   1e-9,
+	"nsec": 1e-9,
+	"us":   1e-6,
+	"usec": 1e-6,
+	"µs":   1e-6,
+	"ms":   1e-3,
+	"msec": 1e-3,
+	"s":    1,
+	"sec":  1,
+}
+
+// parseDuration parses a number in the given time unit and returns it in seconds
+func parseDuration(number, unit string) (float64, error) {
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, err
+	}
+	multiplier, ok := timeUnits[strings.TrimSpace(unit)]
+	if !ok {
+		return 0, fmt.Errorf("unknown time unit: %q", unit)
+	}
+	return value * multiplier, nil
+}
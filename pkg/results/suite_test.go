@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+func TestResults(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Results Suite")
+}
+
+// findMetric returns the value of the metric with the given name and labels
+func findMetric(metrics []perfv1alpha1.BenchmarkMetric, name string, labels map[string]string) string {
+	for _, metric := range metrics {
+		if metric.Name != name || len(metric.Labels) != len(labels) {
+			continue
+		}
+		matches := true
+		for key, value := range labels {
+			if metric.Labels[key] != value {
+				matches = false
+			}
+		}
+		if matches {
+			return metric.Value
+		}
+	}
+	return ""
+}
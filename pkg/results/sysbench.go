@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	sysbenchEventsPerSecRegexp = regexp.MustCompile(`^(?:events per second|events/s \(eps\)):\s+([\d.]+)`)
+	sysbenchTotalTimeRegexp    = regexp.MustCompile(`^(?:total time|time elapsed):\s+([\d.]+)s`)
+	sysbenchTotalEventsRegexp  = regexp.MustCompile(`^total number of events:\s+(\d+)`)
+	sysbenchRateRegexp         = regexp.MustCompile(`^(transactions|queries):\s+\d+\s+\(([\d.]+) per sec\.\)`)
+	sysbenchLatencyRegexp      = regexp.MustCompile(`^(min|avg|max|(\d+)th percentile):\s+([\d.]+)`)
+)
+
+// ParseSysbench parses the summary printed by sysbench 1.x. The events per
+// second metric is computed from the total number of events and the
+// total time when sysbench does not print it (e.g. for the oltp tests).
+func ParseSysbench(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	var eventsPerSec, totalTime, totalEvents float64
+	inLatencySection := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "Latency (ms):") {
+			inLatencySection = true
+			continue
+		}
+		if line == "" {
+			inLatencySection = false
+			continue
+		}
+
+		if inLatencySection {
+			match := sysbenchLatencyRegexp.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			latency, err := parseDuration(match[3], "ms")
+			if err != nil {
+				return nil, err
+			}
+			if match[2] != "" {
+				metrics = append(metrics, newMetric("latency_seconds", latency, UnitSeconds,
+					map[string]string{"percentile": match[2]}))
+			} else {
+				metrics = append(metrics, newMetric("latency_"+match[1]+"_seconds", latency, UnitSeconds, nil))
+			}
+			continue
+		}
+
+		var err error
+		if match := sysbenchEventsPerSecRegexp.FindStringSubmatch(line); match != nil {
+			eventsPerSec, err = strconv.ParseFloat(match[1], 64)
+		} else if match := sysbenchTotalTimeRegexp.FindStringSubmatch(line); match != nil {
+			totalTime, err = strconv.ParseFloat(match[1], 64)
+		} else if match := sysbenchTotalEventsRegexp.FindStringSubmatch(line); match != nil {
+			totalEvents, err = strconv.ParseFloat(match[1], 64)
+		} else if match := sysbenchRateRegexp.FindStringSubmatch(line); match != nil {
+			var rate float64
+			rate, err = strconv.ParseFloat(match[2], 64)
+			metrics = append(metrics, newMetric(match[1]+"_per_second", rate, UnitOperationsPerSecond, nil))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if eventsPerSec == 0 && totalTime > 0 {
+		eventsPerSec = totalEvents / totalTime
+	}
+	if eventsPerSec == 0 && totalEvents == 0 {
+		return nil, ErrNoResults
+	}
+
+	metrics = append([]perfv1alpha1.BenchmarkMetric{
+		newMetric("events_per_second", eventsPerSec, UnitOperationsPerSecond, nil),
+		newMetric("events", totalEvents, "", nil),
+		newMetric("total_time_seconds", totalTime, UnitSeconds, nil),
+	}, metrics...)
+	return metrics, nil
+}
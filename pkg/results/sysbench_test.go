@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const sysbenchCPUOutput = `sysbench 1.0.17 (using bundled LuaJIT 2.1.0-beta2)
+
+CPU speed:
+    events per second:  1234.56
+
+General statistics:
+    total time:                          10.0002s
+    total number of events:              12346
+
+Latency (ms):
+         min:                                    0.79
+         avg:                                    0.81
+         max:                                    1.95
+         95th percentile:                        0.83
+         sum:                                 9995.52
+`
+
+const sysbenchOLTPOutput = `SQL statistics:
+    queries performed:
+        read:                            140000
+    transactions:                        10000  (166.62 per sec.)
+    queries:                             200000 (3332.37 per sec.)
+
+General statistics:
+    total time:                          60.0000s
+    total number of events:              10000
+`
+
+var _ = Describe("sysbench parser", func() {
+	It("parses the events per second and the latencies", func() {
+		metrics, err := ParseSysbench(sysbenchCPUOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "events_per_second", nil)).To(Equal("1234.56"))
+		Expect(findMetric(metrics, "events", nil)).To(Equal("12346"))
+		Expect(findMetric(metrics, "total_time_seconds", nil)).To(Equal("10.0002"))
+		Expect(findMetric(metrics, "latency_avg_seconds", nil)).To(Equal("0.00081"))
+		Expect(findMetric(metrics, "latency_seconds", map[string]string{"percentile": "95"})).To(Equal("0.00083"))
+	})
+
+	It("computes the events per second of the oltp tests", func() {
+		metrics, err := ParseSysbench(sysbenchOLTPOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findMetric(metrics, "events_per_second", nil)).To(Equal("166.6666667"))
+		Expect(findMetric(metrics, "transactions_per_second", nil)).To(Equal("166.62"))
+		Expect(findMetric(metrics, "queries_per_second", nil)).To(Equal("3332.37"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseSysbench("FATAL: Cannot find benchmark 'foo'")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+// ValidateThresholds checks the operators of the thresholds and that
+// their values are quantities
+func ValidateThresholds(thresholds []perfv1alpha1.MetricThreshold) error {
+	for _, threshold := range thresholds {
+		switch threshold.Operator {
+		case perfv1alpha1.LessThan, perfv1alpha1.LessThanOrEqual,
+			perfv1alpha1.GreaterThan, perfv1alpha1.GreaterThanOrEqual:
+		default:
+			return fmt.Errorf("The operator '%s' of the %s threshold is invalid",
+				threshold.Operator, threshold.Metric)
+		}
+		if _, err := resource.ParseQuantity(threshold.Value); err != nil {
+			return fmt.Errorf("The value '%s' of the %s threshold is invalid: %v",
+				threshold.Value, threshold.Metric, err)
+		}
+	}
+	return nil
+}
+
+// EvaluateThresholds checks the metrics against the thresholds and
+// returns the description of the violated thresholds. A threshold is
+// violated when no metric is selected by it as well.
+func EvaluateThresholds(thresholds []perfv1alpha1.MetricThreshold,
+	metrics []perfv1alpha1.BenchmarkMetric) ([]string, error) {
+	var violations []string
+	for _, threshold := range thresholds {
+		quantity, err := resource.ParseQuantity(threshold.Value)
+		if err != nil {
+			return nil, err
+		}
+		limit := quantity.AsDec()
+
+		selected := 0
+		for _, metric := range metrics {
+			if metric.Name != threshold.Metric ||
+				!labels.SelectorFromSet(threshold.Labels).Matches(labels.Set(metric.Labels)) {
+				continue
+			}
+			selected++
+
+			value, err := resource.ParseQuantity(metric.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of metric %s: %v", metric.Name, err)
+			}
+			if !satisfies(value.AsDec().Cmp(limit), threshold.Operator) {
+				violations = append(violations, fmt.Sprintf("%s = %s, expected %s %s",
+					describeMetric(metric), metric.Value, threshold.Operator, threshold.Value))
+			}
+		}
+
+		if selected == 0 {
+			violations = append(violations, fmt.Sprintf("%s not found in the results",
+				describeMetric(perfv1alpha1.BenchmarkMetric{Name: threshold.Metric, Labels: threshold.Labels})))
+		}
+	}
+	return violations, nil
+}
+
+// satisfies tells whether the result of the comparison of the metric with
+// the threshold (-1, 0 or 1) satisfies the operator
+func satisfies(comparison int, operator perfv1alpha1.ThresholdOperator) bool {
+	switch operator {
+	case perfv1alpha1.LessThan:
+		return comparison < 0
+	case perfv1alpha1.LessThanOrEqual:
+		return comparison <= 0
+	case perfv1alpha1.GreaterThan:
+		return comparison > 0
+	case perfv1alpha1.GreaterThanOrEqual:
+		return comparison >= 0
+	}
+	return false
+}
+
+// describeMetric returns the name of the metric followed by its labels
+func describeMetric(metric perfv1alpha1.BenchmarkMetric) string {
+	if len(metric.Labels) == 0 {
+		return metric.Name
+	}
+	return metric.Name + "{" + labels.Set(metric.Labels).String() + "}"
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Thresholds", func() {
+	metrics := []perfv1alpha1.BenchmarkMetric{
+		newMetric("latency_seconds", 0.0015, UnitSeconds, map[string]string{"rw": "read", "percentile": "99"}),
+		newMetric("latency_seconds", 0.0035, UnitSeconds, map[string]string{"rw": "write", "percentile": "99"}),
+		newMetric("bits_per_second", 9.4e9, UnitBitsPerSecond, nil),
+		newMetric("transactions_per_second", 5000, UnitOperationsPerSecond, nil),
+	}
+
+	It("should accept the valid thresholds", func() {
+		Expect(ValidateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "latency_seconds", Operator: perfv1alpha1.LessThan, Value: "2m"},
+			{Metric: "bits_per_second", Operator: perfv1alpha1.GreaterThanOrEqual, Value: "9G"},
+		})).To(Succeed())
+	})
+
+	It("should reject values which are not quantities", func() {
+		Expect(ValidateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "latency_seconds", Operator: perfv1alpha1.LessThan, Value: "2ms"},
+		})).NotTo(Succeed())
+	})
+
+	It("should reject unknown operators", func() {
+		Expect(ValidateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "latency_seconds", Operator: "==", Value: "2m"},
+		})).NotTo(Succeed())
+	})
+
+	It("should pass when every selected metric satisfies the thresholds", func() {
+		violations, err := EvaluateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "latency_seconds", Labels: map[string]string{"rw": "read"},
+				Operator: perfv1alpha1.LessThan, Value: "2m"},
+			{Metric: "bits_per_second", Operator: perfv1alpha1.GreaterThanOrEqual, Value: "9G"},
+			{Metric: "transactions_per_second", Operator: perfv1alpha1.GreaterThanOrEqual, Value: "5k"},
+		}, metrics)
+		Expect(err).To(BeNil())
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should report the metrics violating the thresholds", func() {
+		violations, err := EvaluateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "latency_seconds", Operator: perfv1alpha1.LessThan, Value: "2m"},
+			{Metric: "transactions_per_second", Operator: perfv1alpha1.GreaterThan, Value: "5000"},
+		}, metrics)
+		Expect(err).To(BeNil())
+		Expect(violations).To(HaveLen(2))
+		Expect(violations[0]).To(ContainSubstring("rw=write"))
+		Expect(violations[1]).To(ContainSubstring("transactions_per_second"))
+	})
+
+	It("should report the thresholds without metrics", func() {
+		violations, err := EvaluateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "iops", Operator: perfv1alpha1.GreaterThan, Value: "100"},
+		}, metrics)
+		Expect(err).To(BeNil())
+		Expect(violations).To(ConsistOf("iops not found in the results"))
+	})
+
+	It("should compare values in exponent format", func() {
+		violations, err := EvaluateThresholds([]perfv1alpha1.MetricThreshold{
+			{Metric: "bits_per_second", Operator: perfv1alpha1.LessThan, Value: "9.5G"},
+		}, []perfv1alpha1.BenchmarkMetric{
+			{Name: "bits_per_second", Value: "9.4e+09"},
+		})
+		Expect(err).To(BeNil())
+		Expect(violations).To(BeEmpty())
+	})
+})
@@ -0,0 +1,98 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	"bufio"
+	"regexp"
+	"strconv"
+	"strings"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var (
+	ycsbLineRegexp    = regexp.MustCompile(`^\[([A-Z0-9_-]+)\], ([^,]+), ([\d.]+)\s*$`)
+	ycsbLatencyRegexp = regexp.MustCompile(`^(Average|Min|Max|(\d+)thPercentile)Latency\((\w+)\)$`)
+)
+
+// ParseYcsb parses the measurements printed by YCSB at the end of the
+// run phase. Per operation metrics are labeled with the operation.
+func ParseYcsb(output string) ([]perfv1alpha1.BenchmarkMetric, error) {
+	var metrics []perfv1alpha1.BenchmarkMetric
+	throughputFound := false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		match := ycsbLineRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		section, measurement, number := match[1], match[2], match[3]
+
+		if section == "OVERALL" {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return nil, err
+			}
+			switch measurement {
+			case "Throughput(ops/sec)":
+				metrics = append(metrics, newMetric("throughput_ops_per_second", value, UnitOperationsPerSecond, nil))
+				throughputFound = true
+			case "RunTime(ms)":
+				metrics = append(metrics, newMetric("runtime_seconds", value/1e3, UnitSeconds, nil))
+			}
+			continue
+		}
+
+		labels := map[string]string{"operation": strings.ToLower(section)}
+		if measurement == "Operations" {
+			operations, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return nil, err
+			}
+			metrics = append(metrics, newMetric("operations", operations, "", labels))
+			continue
+		}
+		latencyMatch := ycsbLatencyRegexp.FindStringSubmatch(measurement)
+		if latencyMatch == nil {
+			continue
+		}
+		latency, err := parseDuration(number, latencyMatch[3])
+		if err != nil {
+			return nil, err
+		}
+		if latencyMatch[2] != "" {
+			labels["percentile"] = latencyMatch[2]
+			metrics = append(metrics, newMetric("latency_seconds", latency, UnitSeconds, labels))
+		} else {
+			name := "latency_" + strings.ToLower(latencyMatch[1])
+			if name == "latency_average" {
+				name = "latency_avg"
+			}
+			metrics = append(metrics, newMetric(name+"_seconds", latency, UnitSeconds, labels))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !throughputFound {
+		return nil, ErrNoResults
+	}
+	return metrics, nil
+}
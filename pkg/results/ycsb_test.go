@@ -0,0 +1,59 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const ycsbOutput = `Loading workload...
+Starting test.
+[OVERALL], RunTime(ms), 10110
+[OVERALL], Throughput(ops/sec), 98.91196834817012
+[READ], Operations, 496
+[READ], AverageLatency(us), 657.3
+[READ], MinLatency(us), 186
+[READ], MaxLatency(us), 36127
+[READ], 95thPercentileLatency(us), 1022
+[READ], 99thPercentileLatency(us), 1946
+[READ], Return=OK, 496
+[UPDATE], Operations, 504
+[UPDATE], AverageLatency(us), 1210.5
+`
+
+var _ = Describe("ycsb parser", func() {
+	It("parses the overall and per operation measurements", func() {
+		metrics, err := ParseYcsb(ycsbOutput)
+		Expect(err).NotTo(HaveOccurred())
+
+		read := map[string]string{"operation": "read"}
+		Expect(findMetric(metrics, "throughput_ops_per_second", nil)).To(Equal("98.91196835"))
+		Expect(findMetric(metrics, "runtime_seconds", nil)).To(Equal("10.11"))
+		Expect(findMetric(metrics, "operations", read)).To(Equal("496"))
+		Expect(findMetric(metrics, "latency_avg_seconds", read)).To(Equal("0.0006573"))
+		Expect(findMetric(metrics, "latency_max_seconds", read)).To(Equal("0.036127"))
+		Expect(findMetric(metrics, "latency_seconds",
+			map[string]string{"operation": "read", "percentile": "99"})).To(Equal("0.001946"))
+		Expect(findMetric(metrics, "latency_avg_seconds", map[string]string{"operation": "update"})).To(Equal("0.0012105"))
+	})
+
+	It("fails when the output has no results", func() {
+		_, err := ParseYcsb("Error while loading workload")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+})
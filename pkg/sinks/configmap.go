@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// maxConfigMapSize is the limit of the size of the data of a ConfigMap
+const maxConfigMapSize = 1 << 20
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create
+
+// ConfigMap stores the results and the compressed logs of the payload in a
+// ConfigMap controlled by the benchmark CR, so it is removed along with the CR
+type ConfigMap struct {
+	Access *k8s.Access
+	Owner  metav1.Object
+	Name   string
+}
+
+// Store creates the ConfigMap holding the files of the payload
+func (c *ConfigMap) Store(ctx context.Context, payload *Payload) (string, error) {
+	configMap, err := newConfigMap(c.Name, c.Owner.GetNamespace(), payload)
+	if err != nil {
+		return "", err
+	}
+	if err := c.Access.CreateWithReference(ctx, configMap, c.Owner); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("configmap://%s/%s", configMap.Namespace, configMap.Name), nil
+}
+
+// newConfigMap creates a ConfigMap with results.json and the output of
+// the containers as a compressed archive (logs.tar.gz). The beginning of
+// the longest outputs is dropped to fit the size limit of ConfigMaps.
+func newConfigMap(name, namespace string, payload *Payload) (*corev1.ConfigMap, error) {
+	report, err := payload.Report()
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := maxConfigMapSize - len(ResultsFile) - len(report) - len(LogArchiveKey)
+	if maxSize > maxLogArchiveSize {
+		maxSize = maxLogArchiveSize
+	}
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("the results (%d bytes) exceed the size limit of ConfigMaps", len(report))
+	}
+	archive, _, err := NewLogArchive(payload.Logs, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := corev1.ConfigMap{
+		ObjectMeta: resultsObjectMeta(name, namespace),
+		Data:       map[string]string{ResultsFile: string(report)},
+		BinaryData: map[string][]byte{LogArchiveKey: archive},
+	}
+	return &configMap, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout limits the duration of the requests to the sinks
+const requestTimeout = 30 * time.Second
+
+// client returns the given client or a client with the default timeout
+func client(c *http.Client) *http.Client {
+	if c != nil {
+		return c
+	}
+	return &http.Client{Timeout: requestTimeout}
+}
+
+// HTTP posts the payload, including the logs of the pods, as a JSON
+// document to an HTTP endpoint
+type HTTP struct {
+	URL     string
+	Headers map[string]string
+
+	// Client is used for the requests, defaults to a client with a timeout
+	Client *http.Client
+}
+
+// Store posts the payload to the URL of the sink
+func (h *HTTP) Store(ctx context.Context, payload *Payload) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range h.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client(h.Client).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return "", fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return h.URL, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTP sink", func() {
+	It("should post the payload as JSON", func() {
+		var received Payload
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			authorization = r.Header.Get("Authorization")
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		sink := HTTP{URL: server.URL + "/ingest", Headers: map[string]string{"Authorization": "Bearer token"}}
+		location, err := sink.Store(context.Background(), newPayload())
+		Expect(err).To(BeNil())
+		Expect(location).To(Equal(server.URL + "/ingest"))
+		Expect(authorization).To(Equal("Bearer token"))
+		Expect(received.Name).To(Equal("fio-sample"))
+		Expect(received.Status.Results.Metrics).To(HaveLen(1))
+		Expect(received.Logs).To(HaveKey("fio-sample-x7rzt_fio"))
+	})
+
+	It("should fail when the endpoint does not accept the payload", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		sink := HTTP{URL: server.URL}
+		_, err := sink.Store(context.Background(), newPayload())
+		Expect(err).To(MatchError(ContainSubstring("401")))
+	})
+})
@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+const (
+	// LogArchiveKey is the key of the archive in the binaryData of the ConfigMap
+	LogArchiveKey = "logs.tar.gz"
+
+	// maxLogArchiveSize keeps the archive within the size limit of ConfigMaps
+	maxLogArchiveSize = 900 << 10
+
+	// maxContainerLogSize limits the archived output of a single container
+	maxContainerLogSize = 4 << 20
+
+	// minContainerLogSize is the limit below which the outputs are not
+	// truncated any further to fit the archive into maxLogArchiveSize
+	minContainerLogSize = 1 << 10
+)
+
+// CollectLogs returns the output of the containers, including the init
+// containers, of every pod controlled by the benchmark CR, keyed by
+// <pod>_<container>. The containers whose logs are not available (e.g.
+// they have never started) are skipped.
+func CollectLogs(access *k8s.Access, owner metav1.Object) (map[string]string, error) {
+	pods, err := access.GetControlledPods(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := map[string]string{}
+	for _, pod := range pods {
+		name := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			containerLogs, err := access.GetPodLogs(name, container.Name, maxContainerLogSize)
+			if err != nil {
+				continue
+			}
+			logs[pod.Name+"_"+container.Name] = containerLogs
+		}
+	}
+	return logs, nil
+}
+
+// StoreLogArchive stores the logs of the given run as a compressed archive
+// in the <name>-logs (or <name>-run<run>-logs) ConfigMap controlled by
+// the benchmark CR
+func StoreLogArchive(ctx context.Context, access *k8s.Access, owner metav1.Object, run int32,
+	logs map[string]string) (*perfv1alpha1.LogArchive, error) {
+	archive, truncated, err := NewLogArchive(logs, maxLogArchiveSize)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := corev1.ConfigMap{
+		ObjectMeta: resultsObjectMeta(perfv1alpha1.RunName(owner.GetName(), run)+"-logs", owner.GetNamespace()),
+		BinaryData: map[string][]byte{LogArchiveKey: archive},
+	}
+	if err := access.CreateWithReference(ctx, &configMap, owner); err != nil {
+		return nil, err
+	}
+
+	return &perfv1alpha1.LogArchive{
+		ConfigMap:  configMap.Name,
+		Key:        LogArchiveKey,
+		Containers: int32(len(logs)),
+		Truncated:  truncated,
+	}, nil
+}
+
+// NewLogArchive creates a gzip compressed tarball with a <key>.log file
+// for each of the logs. When the archive would exceed maxSize, the
+// beginning of the longest outputs is dropped, as the summaries of the
+// benchmarks are at the end of their output.
+func NewLogArchive(logs map[string]string, maxSize int) (archive []byte, truncated bool, err error) {
+	for limit := maxContainerLogSize; limit >= minContainerLogSize; limit /= 2 {
+		archive, truncated, err = newTarGz(logs, limit)
+		if err != nil || len(archive) <= maxSize {
+			return archive, truncated, err
+		}
+	}
+	return nil, false, fmt.Errorf("the logs do not fit into %d bytes", maxSize)
+}
+
+// newTarGz creates the archive with the logs truncated to limit bytes
+func newTarGz(logs map[string]string, limit int) ([]byte, bool, error) {
+	keys := make([]string, 0, len(logs))
+	for key := range logs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+	truncated := false
+	for _, key := range keys {
+		content, dropped := k8s.TruncateLogs(logs[key], limit)
+		truncated = truncated || dropped > 0
+		header := tar.Header{
+			Name:    key + ".log",
+			Mode:    0644,
+			Size:    int64(len(content)),
+			ModTime: now,
+		}
+		if err := tarWriter.WriteHeader(&header); err != nil {
+			return nil, false, err
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, false, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, false, err
+	}
+	return buffer.Bytes(), truncated, nil
+}
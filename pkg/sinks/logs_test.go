@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// extract returns the files of the archive
+func extract(archive []byte) map[string]string {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	Expect(err).To(BeNil())
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files
+		}
+		Expect(err).To(BeNil())
+		content, err := ioutil.ReadAll(tarReader)
+		Expect(err).To(BeNil())
+		files[header.Name] = string(content)
+	}
+}
+
+// random generates the outputs, which differ from each other
+var random = rand.New(rand.NewSource(1))
+
+// randomOutput returns poorly compressible output of the given size
+func randomOutput(size int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	output := make([]byte, size)
+	for i := range output {
+		output[i] = letters[random.Intn(len(letters))]
+	}
+	return string(output)
+}
+
+var _ = Describe("Log archive", func() {
+	It("should archive the output of each container", func() {
+		archive, truncated, err := NewLogArchive(map[string]string{
+			"pgbench-sample-x7rzt_pgbench-init": "creating tables...\n",
+			"pgbench-sample-x7rzt_pgbench":      "tps = 1000\n",
+		}, maxLogArchiveSize)
+		Expect(err).To(BeNil())
+		Expect(truncated).To(BeFalse())
+		Expect(extract(archive)).To(Equal(map[string]string{
+			"pgbench-sample-x7rzt_pgbench-init.log": "creating tables...\n",
+			"pgbench-sample-x7rzt_pgbench.log":      "tps = 1000\n",
+		}))
+	})
+
+	It("should keep the end of the outputs exceeding the size limit", func() {
+		output := randomOutput(64<<10) + "summary\n"
+		archive, truncated, err := NewLogArchive(map[string]string{
+			"iperf3-sample-x7rzt_iperf3": output,
+		}, 16<<10)
+		Expect(err).To(BeNil())
+		Expect(truncated).To(BeTrue())
+		Expect(len(archive)).To(BeNumerically("<=", 16<<10))
+
+		archived := extract(archive)["iperf3-sample-x7rzt_iperf3.log"]
+		Expect(archived).To(HavePrefix("["))
+		Expect(archived).To(ContainSubstring("bytes truncated]"))
+		Expect(strings.HasSuffix(output, strings.SplitN(archived, "\n", 2)[1])).To(BeTrue())
+	})
+
+	It("should fail when the logs cannot be fit into the size limit", func() {
+		logs := map[string]string{}
+		for _, pod := range []string{"a", "b", "c", "d"} {
+			logs[pod+"_fio"] = randomOutput(4 << 10)
+		}
+		_, _, err := NewLogArchive(logs, 1<<10)
+		Expect(err).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// PVCSinkImage is the name of the image of the job copying the results to
+// the volume in the image catalog of the operator
+const PVCSinkImage = "pvcsink"
+
+const (
+	// copyJobDeadline is the time the job copying the results to the
+	// volume has to finish, including the retries
+	copyJobDeadline = int64(600)
+
+	// copyJobBackoffLimit is the number of retries of the copy job
+	copyJobBackoffLimit = int32(2)
+)
+
+// PVC copies the files of the payload to a PersistentVolumeClaim. As the
+// volume is not mounted to the operator, the files are stored in a
+// ConfigMap first and copied to the volume by a job mounting both, which
+// extracts the log archive of the ConfigMap.
+type PVC struct {
+	Access *k8s.Access
+	Owner  metav1.Object
+	// Name of the ConfigMap and the Job created by the sink
+	Name string
+	Spec perfv1alpha1.PVCSink
+	// Image of the job copying the files to the volume
+	Image perfv1alpha1.ImageSpec
+}
+
+// Store creates the ConfigMap holding the files of the payload and the
+// job copying them to <path>/<namespace>/<name>/ on the volume. The
+// delivery is finished once the job has finished, see Delivered.
+func (p *PVC) Store(ctx context.Context, payload *Payload) (string, error) {
+	namespace := p.Owner.GetNamespace()
+	configMap, err := newConfigMap(p.Name, namespace, payload)
+	if err != nil {
+		return "", err
+	}
+	if err := p.Access.CreateWithReference(ctx, configMap, p.Owner); err != nil {
+		return "", err
+	}
+
+	dir := path.Join(p.Spec.Path, payload.Dir())
+	job := newCopyJob(p.Name, namespace, p.Spec.ClaimName, p.Image, dir)
+	if err := p.Access.CreateWithReference(ctx, job, p.Owner); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("pvc://%s/%s/", p.Spec.ClaimName, dir), nil
+}
+
+// Delivered returns the outcome of the job copying the files to the volume
+func (p *PVC) Delivered(ctx context.Context) (bool, error) {
+	outcome, err := p.Access.GetJobOutcome(types.NamespacedName{
+		Namespace: p.Owner.GetNamespace(),
+		Name:      p.Name,
+	})
+	if apierrors.IsNotFound(err) {
+		return true, errors.New("the job copying the results to the volume has been deleted")
+	}
+	if err != nil {
+		return false, err
+	}
+	if !outcome.Finished() {
+		return false, nil
+	}
+	if outcome.Phase == k8s.JobFailed {
+		return true, errors.New("copying the results to the volume failed: " + outcome.Message)
+	}
+	return true, nil
+}
+
+// newCopyJob creates the job copying the files of the ConfigMap name to
+// the directory dir of the volume claimName
+func newCopyJob(name, namespace, claimName string, image perfv1alpha1.ImageSpec, dir string) *batchv1.Job {
+	job := k8s.NewPerfJob(resultsObjectMeta(name, namespace),
+		"sink", image, perfv1alpha1.PodConfigurationSpec{})
+	deadline, backoffLimit := copyJobDeadline, copyJobBackoffLimit
+	job.Spec.ActiveDeadlineSeconds = &deadline
+	job.Spec.BackoffLimit = &backoffLimit
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = []corev1.Volume{
+		{
+			Name: "results",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				},
+			},
+		},
+		{
+			Name: "sink",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			},
+		},
+	}
+
+	container := &podSpec.Containers[0]
+	container.Command = []string{"/bin/sh", "-c",
+		`mkdir -p "$DEST" && cp -L /results/` + ResultsFile + ` "$DEST"/ && tar -xzf /results/` + LogArchiveKey + ` -C "$DEST"`}
+	container.Env = []corev1.EnvVar{{Name: "DEST", Value: path.Join("/sink", dir)}}
+	container.VolumeMounts = []corev1.VolumeMount{
+		{Name: "results", MountPath: "/results", ReadOnly: true},
+		{Name: "sink", MountPath: "/sink"},
+	}
+	return job
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+var _ = Describe("PVC sink", func() {
+	It("should store the results and the archived logs in a ConfigMap", func() {
+		configMap, err := newConfigMap("fio-sample-sink-0", "kubestone", newPayload())
+		Expect(err).To(BeNil())
+		Expect(configMap.Data).To(HaveKey(ResultsFile))
+		Expect(extract(configMap.BinaryData[LogArchiveKey])).To(
+			HaveKeyWithValue("fio-sample-x7rzt_fio.log", "fio-3.13\n"))
+	})
+
+	It("should truncate the output exceeding the size limit of ConfigMaps", func() {
+		payload := newPayload()
+		payload.Logs["fio-sample-x7rzt_fio"] = randomOutput(2*maxConfigMapSize) + "summary\n"
+		configMap, err := newConfigMap("fio-sample-sink-0", "kubestone", payload)
+		Expect(err).To(BeNil())
+		size := len(configMap.BinaryData[LogArchiveKey]) + len(configMap.Data[ResultsFile])
+		Expect(size).To(BeNumerically("<", maxConfigMapSize))
+		Expect(extract(configMap.BinaryData[LogArchiveKey])["fio-sample-x7rzt_fio.log"]).To(
+			HaveSuffix("summary\n"))
+	})
+
+	It("should copy the files to the directory of the volume", func() {
+		job := newCopyJob("fio-sample-sink-0", "kubestone", "results",
+			perfv1alpha1.ImageSpec{Name: "alpine:3", PullSecret: "registry"}, "benchmarks/kubestone/fio-sample")
+		Expect(job.Labels).To(HaveKey(ResultsLabel))
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(HaveLen(2))
+		Expect(podSpec.Volumes[0].ConfigMap.Name).To(Equal("fio-sample-sink-0"))
+		Expect(podSpec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("results"))
+		Expect(podSpec.Containers[0].Image).To(Equal("alpine:3"))
+		Expect(podSpec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "registry"}))
+		Expect(podSpec.Containers[0].Env[0].Value).To(Equal("/sink/benchmarks/kubestone/fio-sample"))
+		Expect(podSpec.Containers[0].Command[2]).To(ContainSubstring("tar -xzf /results/" + LogArchiveKey))
+		Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(copyJobDeadline))
+		Expect(*job.Spec.BackoffLimit).To(Equal(copyJobBackoffLimit))
+	})
+
+	Describe("delivery", func() {
+		var clientset *fakeclientset.Clientset
+		var sink *PVC
+
+		BeforeEach(func() {
+			clientset = fakeclientset.NewSimpleClientset()
+			sink = &PVC{
+				Access: &k8s.Access{Clientset: clientset},
+				Owner:  &metav1.ObjectMeta{Name: "fio-sample", Namespace: "kubestone"},
+				Name:   "fio-sample-sink-0",
+			}
+		})
+
+		// createCopyJob creates the copy job of the sink with the given conditions
+		createCopyJob := func(conditions ...batchv1.JobCondition) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: sink.Name, Namespace: "kubestone"},
+				Status:     batchv1.JobStatus{Conditions: conditions},
+			}
+			_, err := clientset.BatchV1().Jobs("kubestone").Create(job)
+			Expect(err).To(BeNil())
+		}
+
+		It("should be in progress while the copy job is running", func() {
+			createCopyJob()
+			Expect(sink.Delivered(context.Background())).To(BeFalse())
+		})
+
+		It("should succeed once the copy job has succeeded", func() {
+			createCopyJob(batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})
+			Expect(sink.Delivered(context.Background())).To(BeTrue())
+		})
+
+		It("should fail when the copy job has failed", func() {
+			createCopyJob(batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue,
+				Reason: "DeadlineExceeded", Message: "Job was active longer than specified deadline"})
+			finished, err := sink.Delivered(context.Background())
+			Expect(finished).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("Job was active longer than specified deadline")))
+		})
+
+		It("should fail when the copy job has been deleted", func() {
+			finished, err := sink.Delivered(context.Background())
+			Expect(finished).To(BeTrue())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("should resolve the image of the copy job through the image defaults of the operator", func() {
+		owner := &metav1.ObjectMeta{Name: "fio-sample", Namespace: "kubestone"}
+		spec := perfv1alpha1.ResultSink{PVC: &perfv1alpha1.PVCSink{ClaimName: "results"}}
+		sink, err := New(nil, owner, 1, spec, 0, func(component string, image *perfv1alpha1.ImageSpec) {
+			Expect(component).To(Equal(PVCSinkImage))
+			image.Name = "mirror.local/alpine:3"
+			image.PullSecret = "mirror"
+		})
+		Expect(err).To(BeNil())
+		Expect(sink.(*PVC).Image).To(Equal(perfv1alpha1.ImageSpec{Name: "mirror.local/alpine:3", PullSecret: "mirror"}))
+	})
+})
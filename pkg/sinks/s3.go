@@ -0,0 +1,179 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultS3Region is used when the region of the bucket is not specified
+const DefaultS3Region = "us-east-1"
+
+// S3 uploads the files of the payload to an S3 compatible bucket. The
+// requests are signed with AWS Signature Version 4 and use path-style
+// addressing, which is supported by MinIO and AWS S3 as well.
+type S3 struct {
+	Endpoint        string
+	Bucket          string
+	Prefix          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Client is used for the requests, defaults to a client with a timeout
+	Client *http.Client
+}
+
+// Store uploads the files of the payload under <prefix>/<namespace>/<name>/
+func (s *S3) Store(ctx context.Context, payload *Payload) (string, error) {
+	files, err := payload.Files()
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dir := path.Join(s.Prefix, payload.Dir())
+	for _, name := range names {
+		if err := s.put(ctx, path.Join(dir, name), files[name]); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("s3://%s/%s/", s.Bucket, strings.TrimPrefix(dir, "/")), nil
+}
+
+// put uploads a single object
+func (s *S3) put(ctx context.Context, key string, body []byte) error {
+	objectURL, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return err
+	}
+	objectURL.Path = "/" + path.Join(strings.Trim(objectURL.Path, "/"), s.Bucket, key)
+	objectURL.RawPath = uriEncode(objectURL.Path)
+
+	req, err := http.NewRequest(http.MethodPut, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType(key))
+	s.sign(req, hexSHA256(body), time.Now())
+
+	resp, err := client(s.Client).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unable to upload %s: %s: %s", key, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// sign adds the AWS Signature Version 4 headers to the request
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	region := s.Region
+	if region == "" {
+		region = DefaultS3Region
+	}
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + s.SecretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// uriEncode encodes each byte of the path except the unreserved
+// characters and the slashes as required by Signature Version 4
+func uriEncode(path string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(path) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/':
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// contentType returns the media type of the files of the payload
+func contentType(name string) string {
+	if strings.HasSuffix(name, ".json") {
+		return "application/json"
+	}
+	return "text/plain; charset=utf-8"
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("S3 sink", func() {
+	It("should sign the requests with Signature Version 4", func() {
+		s3 := S3{AccessKeyID: "minio", SecretAccessKey: "minio123"}
+		req, err := http.NewRequest(http.MethodPut,
+			"http://minio:9000/results/perf/kubestone/fio-sample/results.json", strings.NewReader("{}"))
+		Expect(err).To(BeNil())
+
+		s3.sign(req, hexSHA256([]byte("{}")), time.Date(2019, 11, 1, 2, 0, 0, 0, time.UTC))
+		Expect(req.Header.Get("X-Amz-Date")).To(Equal("20191101T020000Z"))
+		Expect(req.Header.Get("Authorization")).To(Equal(
+			"AWS4-HMAC-SHA256 Credential=minio/20191101/us-east-1/s3/aws4_request, " +
+				"SignedHeaders=host;x-amz-content-sha256;x-amz-date, " +
+				"Signature=731df5565207f3c8838bd5f73bb003d6f6ec9bb76ae1335ef73ff769f2ef6b7c"))
+	})
+
+	It("should encode the keys", func() {
+		Expect(uriEncode("/results/run 1/a+b.log")).To(Equal("/results/run%201/a%2Bb.log"))
+	})
+
+	It("should upload the files under the prefix", func() {
+		uploaded := map[string]string{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPut))
+			Expect(r.Header.Get("Authorization")).To(HavePrefix("AWS4-HMAC-SHA256 Credential=minio/"))
+			body, _ := ioutil.ReadAll(r.Body)
+			uploaded[r.URL.Path] = string(body)
+		}))
+		defer server.Close()
+
+		s3 := S3{Endpoint: server.URL, Bucket: "results", Prefix: "perf",
+			AccessKeyID: "minio", SecretAccessKey: "minio123"}
+		location, err := s3.Store(context.Background(), newPayload())
+		Expect(err).To(BeNil())
+		Expect(location).To(Equal("s3://results/perf/kubestone/fio-sample/"))
+		Expect(uploaded).To(HaveKey("/results/perf/kubestone/fio-sample/results.json"))
+		Expect(uploaded).To(HaveKeyWithValue("/results/perf/kubestone/fio-sample/fio-sample-x7rzt_fio.log", "fio-3.13\n"))
+	})
+
+	It("should report the errors of the service", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		s3 := S3{Endpoint: server.URL, Bucket: "results"}
+		_, err := s3.Store(context.Background(), newPayload())
+		Expect(err).To(MatchError(ContainSubstring("NoSuchBucket")))
+	})
+})
@@ -0,0 +1,253 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+	"github.com/xridge/kubestone/pkg/k8s"
+)
+
+// ResultsLabel marks the objects holding the results or the logs of a
+// benchmark, which are kept when the benchmark is cleaned up
+const ResultsLabel = "kubestone.xridge.io/results"
+
+// ResultsFile is the name of the file holding the status and the
+// results of the benchmark in the sinks storing files
+const ResultsFile = "results.json"
+
+// Sink is a destination of the raw output and the results of the
+// finished benchmarks
+type Sink interface {
+	// Store delivers the payload to the sink and returns the location
+	// of the delivered results
+	Store(ctx context.Context, payload *Payload) (location string, err error)
+}
+
+// Tracker is implemented by the sinks which complete the delivery
+// asynchronously, after Store has returned
+type Tracker interface {
+	// Delivered returns true once the delivery started by Store has
+	// finished, along with the reason of the failed delivery
+	Delivered(ctx context.Context) (finished bool, err error)
+}
+
+// Payload is the data delivered to the sinks
+type Payload struct {
+	Kind      string                        `json:"kind"`
+	Namespace string                        `json:"namespace"`
+	Name      string                        `json:"name"`
+	Run       int32                         `json:"run,omitempty"`
+	Labels    map[string]string             `json:"labels,omitempty"`
+	Status    *perfv1alpha1.BenchmarkStatus `json:"status"`
+
+	// Logs contains the output of the containers of the benchmark keyed
+	// by <pod>_<container>
+	Logs map[string]string `json:"logs,omitempty"`
+}
+
+// Dir returns the directory of the results relative to the root of the
+// sinks storing files: <namespace>/<name> for the first run of the
+// benchmark and <namespace>/<name>-run<run> for the subsequent ones
+func (p *Payload) Dir() string {
+	return path.Join(p.Namespace, perfv1alpha1.RunName(p.Name, p.Run))
+}
+
+// Report returns the content of results.json: the payload without the logs
+func (p *Payload) Report() ([]byte, error) {
+	report := *p
+	report.Logs = nil
+	return json.MarshalIndent(&report, "", "  ")
+}
+
+// Files returns the payload as files: the status along with the results
+// as results.json and the output of each container as <pod>_<container>.log
+func (p *Payload) Files() (map[string][]byte, error) {
+	data, err := p.Report()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{ResultsFile: data}
+	for container, logs := range p.Logs {
+		files[container+".log"] = []byte(logs)
+	}
+	return files, nil
+}
+
+// resultsObjectMeta returns the metadata of the objects holding results
+func resultsObjectMeta(name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{ResultsLabel: "true"},
+	}
+}
+
+// ImageDefaulter fills the empty fields of image with the defaults of the
+// operator for the given component (e.g. pvcsink)
+type ImageDefaulter func(component string, image *perfv1alpha1.ImageSpec)
+
+// New creates the sink described by spec for the given run of the
+// benchmark CR owner. The run and the index of the sink in the spec make
+// the names of the objects created by the sink unique. The images of the
+// jobs created by the sinks are defaulted by images.
+func New(access *k8s.Access, owner metav1.Object, run int32, spec perfv1alpha1.ResultSink, index int,
+	images ImageDefaulter) (Sink, error) {
+	namespace := owner.GetNamespace()
+	switch {
+	case spec.S3 != nil:
+		accessKeyID, err := access.GetSecretValue(namespace, spec.S3.AccessKeyID)
+		if err != nil {
+			return nil, err
+		}
+		secretAccessKey, err := access.GetSecretValue(namespace, spec.S3.SecretAccessKey)
+		if err != nil {
+			return nil, err
+		}
+		return &S3{
+			Endpoint:        spec.S3.Endpoint,
+			Bucket:          spec.S3.Bucket,
+			Prefix:          spec.S3.Prefix,
+			Region:          spec.S3.Region,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+		}, nil
+
+	case spec.PVC != nil:
+		image := perfv1alpha1.ImageSpec{Name: spec.PVC.Image}
+		images(PVCSinkImage, &image)
+		return &PVC{
+			Access: access,
+			Owner:  owner,
+			Name:   fmt.Sprintf("%s-sink-%d", perfv1alpha1.RunName(owner.GetName(), run), index),
+			Spec:   *spec.PVC,
+			Image:  image,
+		}, nil
+
+	case spec.HTTP != nil:
+		headers := map[string]string{}
+		for key, value := range spec.HTTP.Headers {
+			headers[key] = value
+		}
+		if spec.HTTP.AuthorizationSecretRef != nil {
+			authorization, err := access.GetSecretValue(namespace, *spec.HTTP.AuthorizationSecretRef)
+			if err != nil {
+				return nil, err
+			}
+			headers["Authorization"] = authorization
+		}
+		return &HTTP{URL: spec.HTTP.URL, Headers: headers}, nil
+
+	case spec.ConfigMap != nil:
+		name := perfv1alpha1.RunName(spec.ConfigMap.Name, run)
+		if spec.ConfigMap.Name == "" {
+			name = perfv1alpha1.RunName(owner.GetName(), run) + "-results"
+		}
+		return &ConfigMap{Access: access, Owner: owner, Name: name}, nil
+	}
+
+	return nil, errors.New("no sink type is specified")
+}
+
+// TypeOf returns the type of the sink as recorded in the status:
+// S3, PVC, HTTP or ConfigMap
+func TypeOf(spec perfv1alpha1.ResultSink) string {
+	switch {
+	case spec.S3 != nil:
+		return "S3"
+	case spec.PVC != nil:
+		return "PVC"
+	case spec.HTTP != nil:
+		return "HTTP"
+	case spec.ConfigMap != nil:
+		return "ConfigMap"
+	}
+	return ""
+}
+
+// Validate checks the sinks of a benchmark spec
+func Validate(specs []perfv1alpha1.ResultSink) error {
+	for i, spec := range specs {
+		if err := validate(spec); err != nil {
+			return fmt.Errorf("sinks[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func validate(spec perfv1alpha1.ResultSink) error {
+	count := 0
+	for _, set := range []bool{spec.S3 != nil, spec.PVC != nil, spec.HTTP != nil, spec.ConfigMap != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return errors.New("exactly one of s3, pvc, http and configMap has to be specified")
+	}
+
+	switch {
+	case spec.S3 != nil:
+		if err := validateURL(spec.S3.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint: %v", err)
+		}
+		if spec.S3.Bucket == "" {
+			return errors.New("bucket is required")
+		}
+		for _, selector := range []corev1.SecretKeySelector{spec.S3.AccessKeyID, spec.S3.SecretAccessKey} {
+			if selector.Name == "" || selector.Key == "" {
+				return errors.New("the name and the key of the credential secrets are required")
+			}
+		}
+	case spec.PVC != nil:
+		if spec.PVC.ClaimName == "" {
+			return errors.New("claimName is required")
+		}
+		clean := path.Clean(spec.PVC.Path)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("path %q is not within the volume", spec.PVC.Path)
+		}
+	case spec.HTTP != nil:
+		if err := validateURL(spec.HTTP.URL); err != nil {
+			return fmt.Errorf("invalid url: %v", err)
+		}
+	}
+	return nil
+}
+
+// validateURL accepts absolute http and https URLs
+func validateURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", rawURL)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+var _ = Describe("Sinks", func() {
+	Describe("payload", func() {
+		It("should provide the results and the logs as files", func() {
+			files, err := newPayload().Files()
+			Expect(err).To(BeNil())
+			Expect(files).To(HaveLen(2))
+			Expect(string(files["fio-sample-x7rzt_fio.log"])).To(Equal("fio-3.13\n"))
+
+			var report map[string]interface{}
+			Expect(json.Unmarshal(files[ResultsFile], &report)).To(Succeed())
+			Expect(report).To(HaveKeyWithValue("kind", "Fio"))
+			Expect(report).To(HaveKey("status"))
+			Expect(report).NotTo(HaveKey("logs"))
+		})
+
+		It("should be stored under the namespace and the name of the CR", func() {
+			Expect(newPayload().Dir()).To(Equal("kubestone/fio-sample"))
+		})
+	})
+
+	Describe("validation", func() {
+		credentials := corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "minio"},
+			Key:                  "accesskey",
+		}
+
+		It("should accept valid sinks", func() {
+			Expect(Validate([]perfv1alpha1.ResultSink{
+				{S3: &perfv1alpha1.S3Sink{Endpoint: "http://minio:9000", Bucket: "results",
+					AccessKeyID: credentials, SecretAccessKey: credentials}},
+				{PVC: &perfv1alpha1.PVCSink{ClaimName: "results", Path: "benchmarks"}},
+				{HTTP: &perfv1alpha1.HTTPSink{URL: "https://results.example.com/ingest"}},
+				{ConfigMap: &perfv1alpha1.ConfigMapSink{}},
+			})).To(Succeed())
+		})
+
+		It("should require exactly one sink type", func() {
+			Expect(Validate([]perfv1alpha1.ResultSink{{}})).NotTo(Succeed())
+			Expect(Validate([]perfv1alpha1.ResultSink{{
+				PVC:       &perfv1alpha1.PVCSink{ClaimName: "results"},
+				ConfigMap: &perfv1alpha1.ConfigMapSink{},
+			}})).NotTo(Succeed())
+		})
+
+		It("should reject incomplete S3 sinks", func() {
+			err := Validate([]perfv1alpha1.ResultSink{
+				{S3: &perfv1alpha1.S3Sink{Endpoint: "http://minio:9000", Bucket: "results",
+					AccessKeyID: credentials}},
+			})
+			Expect(err).To(MatchError(ContainSubstring("sinks[0]")))
+		})
+
+		It("should reject paths outside of the volume", func() {
+			for _, path := range []string{"/data", "..", "results/../../data"} {
+				Expect(Validate([]perfv1alpha1.ResultSink{
+					{PVC: &perfv1alpha1.PVCSink{ClaimName: "results", Path: path}},
+				})).NotTo(Succeed(), path)
+			}
+		})
+
+		It("should reject URLs which are not http(s)", func() {
+			for _, url := range []string{"results.example.com", "ftp://results.example.com", "http://"} {
+				Expect(Validate([]perfv1alpha1.ResultSink{
+					{HTTP: &perfv1alpha1.HTTPSink{URL: url}},
+				})).NotTo(Succeed(), url)
+			}
+		})
+	})
+})
@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The xridge kubestone contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sinks
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	perfv1alpha1 "github.com/xridge/kubestone/api/v1alpha1"
+)
+
+func TestSinks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sinks Suite")
+}
+
+// newPayload returns the payload of a succeeded fio benchmark
+func newPayload() *Payload {
+	return &Payload{
+		Kind:      "Fio",
+		Namespace: "kubestone",
+		Name:      "fio-sample",
+		Status: &perfv1alpha1.BenchmarkStatus{
+			Phase: perfv1alpha1.BenchmarkSucceeded,
+			Results: &perfv1alpha1.BenchmarkResults{Metrics: []perfv1alpha1.BenchmarkMetric{
+				{Name: "iops", Value: "470", Unit: "1/s"},
+			}},
+		},
+		Logs: map[string]string{"fio-sample-x7rzt_fio": "fio-3.13\n"},
+	}
+}
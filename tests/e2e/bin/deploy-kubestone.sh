@@ -17,6 +17,7 @@
 set -eEuo pipefail
 
 DOCKER_IMAGE="xridge/kubestone:e2e"
+CERT_MANAGER_MANIFEST="https://github.com/jetstack/cert-manager/releases/download/v0.10.1/cert-manager.yaml"
 KUBESTONE_ROOT=$(dirname $0)/../../../
 
 build_kubestone() {
@@ -29,6 +30,14 @@ upload_kubestone_to_kind() {
     kind load --loglevel debug docker-image ${DOCKER_IMAGE}
 }
 
+# The admission webhooks of Kubestone get their certificate from cert-manager
+install_cert_manager() {
+    kubectl apply --validate=false -f ${CERT_MANAGER_MANIFEST}
+    kubectl -n cert-manager \
+        wait --for=condition=Available --timeout=2m \
+        deployments --all
+}
+
 deploy_kubestone() {
     pushd ${KUBESTONE_ROOT}
     make deploy-e2e
@@ -48,6 +57,7 @@ show_all_objects() {
 main() {
     build_kubestone
     upload_kubestone_to_kind
+    install_cert_manager
     deploy_kubestone
     validate_kubestone_deployment
     show_all_objects
@@ -72,7 +72,7 @@ var _ = Describe("end to end test", func() {
 						if err := client.Get(ctx, namespacedName, cr); err != nil {
 							Fail("Unable to get fio CR: " + err.Error())
 						}
-						return !cr.Status.Running && cr.Status.Completed
+						return cr.Status.Phase == v1alpha1.BenchmarkSucceeded
 					}, timeout).Should(BeTrue())
 				})
 				It("Should leave a successful job", func() {
@@ -48,7 +48,7 @@ var _ = Describe("ioping end to end test", func() {
 				if err := client.Get(ctx, namespacedName, cr); err != nil {
 					Fail("Unable to get ioping CR")
 				}
-				return (cr.Status.Running == false) && (cr.Status.Completed)
+				return cr.Status.Phase == v1alpha1.BenchmarkSucceeded
 			}, timeout).Should(BeTrue())
 		})
 		It("Should leave one successful job", func() {
@@ -50,7 +50,7 @@ var _ = Describe("iperf3 end to end test", func() {
 				if err := client.Get(ctx, namespacedName, cr); err != nil {
 					Fail("Unable to get iperf3 CR")
 				}
-				return (cr.Status.Running == false) && (cr.Status.Completed)
+				return cr.Status.Phase == v1alpha1.BenchmarkSucceeded
 			}, timeout).Should(BeTrue())
 		})
 		It("Should leave a successful job", func() {